@@ -13,6 +13,7 @@ import (
 
 	"github.com/gardener/landscapercli/cmd/blueprints"
 	"github.com/gardener/landscapercli/cmd/completion"
+	"github.com/gardener/landscapercli/cmd/deployitems"
 	"github.com/gardener/landscapercli/cmd/installations"
 	"github.com/gardener/landscapercli/cmd/quickstart"
 	"github.com/gardener/landscapercli/cmd/targets"
@@ -42,6 +43,7 @@ func NewLandscaperCliCommand(ctx context.Context) *cobra.Command {
 	cmd.AddCommand(quickstart.NewQuickstartCommand(ctx))
 	cmd.AddCommand(installations.NewInstallationsCommand(ctx))
 	cmd.AddCommand(targets.NewTargetsCommand(ctx))
+	cmd.AddCommand(deployitems.NewDeployItemsCommand(ctx))
 	cmd.AddCommand(completion.NewCompletionCommand())
 
 	return cmd
@@ -0,0 +1,32 @@
+package deployitems
+
+import (
+	"context"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+)
+
+var (
+	scheme = runtime.NewScheme()
+)
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = lsv1alpha1.AddToScheme(scheme)
+}
+
+// NewDeployItemsCommand creates a new deployitems command.
+func NewDeployItemsCommand(ctx context.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "deployitems",
+		Aliases: []string{"di", "deployitem"},
+		Short:   "commands to interact with deployitems",
+	}
+
+	cmd.AddCommand(NewOperationCommand(ctx))
+
+	return cmd
+}
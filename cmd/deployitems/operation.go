@@ -0,0 +1,35 @@
+package deployitems
+
+import (
+	"context"
+	"os"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/landscapercli/pkg/operations"
+)
+
+func NewOperationCommand(ctx context.Context) *cobra.Command {
+	opts := operations.NewCommandOptions("deployitem", operations.DeployItemOperations)
+	cmd := &cobra.Command{
+		Use:     "operation [deployitem-name] --op operation [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.ExactArgs(1),
+		Example: "landscaper-cli deployitems operation MY_DEPLOYITEM --op reconcile-time --namespace MY_NAMESPACE",
+		Short:   "Triggers a landscaper operation on a deployitem by setting the corresponding annotation.",
+		Long: "Triggers a landscaper operation on a deployitem by setting the corresponding annotation. Who " +
+			"triggered the operation is recorded in the annotation " + operations.TriggeredByAnnotation + ". The " +
+			"following operations are supported:\n\n" + operations.Describe(operations.DeployItemOperations),
+		Run: opts.Run(ctx, scheme, func() client.Object { return &lsv1alpha1.DeployItem{} }, func(obj client.Object, op *operations.Operation) error {
+			op.Annotate(&obj.(*lsv1alpha1.DeployItem).ObjectMeta)
+			return nil
+		}),
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
@@ -10,6 +10,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/gardener/landscapercli/pkg/operations"
 	"github.com/gardener/landscapercli/pkg/util"
 )
 
@@ -42,20 +43,22 @@ func (o *annotationOptions) run(ctx context.Context) error {
 	}
 
 	installationKey := client.ObjectKey{Namespace: o.namespace, Name: o.installationName}
-	installation := &v1alpha1.Installation{}
-	if err := kubeClient.Get(ctx, installationKey, installation); err != nil {
-		return fmt.Errorf("failed to read installation: %w", err)
-	}
-
-	if o.rootInstallationsOnly && !installations.IsRootInstallation(installation) {
-		return fmt.Errorf("the command is only supported for root installations")
-	}
+	return o.annotate(ctx, kubeClient, installationKey)
+}
 
-	v1.SetMetaDataAnnotation(&installation.ObjectMeta, o.annotationKey, o.annotationValue)
-	if err := kubeClient.Update(ctx, installation); err != nil {
-		return fmt.Errorf("failed to update installation: %w", err)
+// annotate sets the annotation on the installation. Conflicting updates are retried.
+func (o *annotationOptions) annotate(ctx context.Context, kubeClient client.Client, installationKey client.ObjectKey) error {
+	installation := &v1alpha1.Installation{}
+	err := operations.Apply(ctx, kubeClient, installationKey, installation, operations.DefaultTriggeredBy(), func() error {
+		if o.rootInstallationsOnly && !installations.IsRootInstallation(installation) {
+			return fmt.Errorf("the command is only supported for root installations")
+		}
+		v1.SetMetaDataAnnotation(&installation.ObjectMeta, o.annotationKey, o.annotationValue)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to annotate installation %s: %w", installationKey.Name, err)
 	}
-
 	return nil
 }
 
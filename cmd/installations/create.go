@@ -0,0 +1,177 @@
+package installations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	cdv2 "github.com/gardener/landscaper/legacy-component-spec/bindings-go/apis/v2"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/gardener/landscapercli/pkg/blueprints"
+)
+
+type createOptions struct {
+	installationName string
+	namespace        string
+
+	blueprintDir string
+	component    string
+	resourceName string
+
+	imports []string
+	targets []string
+}
+
+func NewCreateCommand(ctx context.Context) *cobra.Command {
+	opts := &createOptions{}
+	cmd := &cobra.Command{
+		Use:  "create [installation-name] (--blueprint-dir dir | --component ref --resource-name blueprint --blueprint-dir dir) [--import name=value...] [--target name=target...] [--namespace namespace]",
+		Args: cobra.ExactArgs(1),
+		Example: "landscaper-cli installations create MY_INSTALLATION --blueprint-dir ./blueprint --import replicas=3 --target cluster=my-target\n" +
+			"landscaper-cli installations create MY_INSTALLATION --component example.com/components//github.com/acme/app:v1.0.0 --resource-name blueprint --blueprint-dir ./blueprint",
+		Short: "Prints an installation manifest for a blueprint, in which every import and export of the blueprint is " +
+			"already wired. The imports are marked as required or optional in comments.",
+		Long: "Prints an installation manifest for a blueprint, in which every import and export of the blueprint is " +
+			"already wired. Data imports reference a DataObject with the name of the import, or are set as " +
+			"importDataMappings with --import or if they are optional and have a default value. Target imports reference " +
+			"a Target with the name of the import, or the target given with --target. Exports are written to DataObjects " +
+			"and Targets with the name of the export.\n\n" +
+			"With --blueprint-dir only, the blueprint is added inline to the installation. With --component and " +
+			"--resource-name, the installation references the blueprint resource of the component version, given as " +
+			"REPOSITORY_URL//COMPONENT_NAME:VERSION. The blueprint is not downloaded from the component repository, so " +
+			"--blueprint-dir is still required: the imports and exports are taken from the local copy of the blueprint, " +
+			"which must match the blueprint resource of the component version.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *createOptions) run(_ context.Context, cmd *cobra.Command) error {
+	blueprintReader := blueprints.NewBlueprintReader(o.blueprintDir)
+	blueprint, err := blueprintReader.Read()
+	if err != nil {
+		return fmt.Errorf("cannot read blueprint from %s: %w", o.blueprintDir, err)
+	}
+
+	installation := &lsv1alpha1.Installation{}
+	installation.Name = o.installationName
+	installation.Namespace = o.namespace
+
+	if o.component != "" {
+		componentReference, err := parseComponentReference(o.component)
+		if err != nil {
+			return err
+		}
+		installation.Spec.ComponentDescriptor = &lsv1alpha1.ComponentDescriptorDefinition{
+			Reference: componentReference,
+		}
+		installation.Spec.Blueprint.Reference = &lsv1alpha1.RemoteBlueprintReference{
+			ResourceName: o.resourceName,
+		}
+	} else {
+		filesystem, err := blueprintReader.ReadFilesystem()
+		if err != nil {
+			return fmt.Errorf("cannot read blueprint directory %s: %w", o.blueprintDir, err)
+		}
+		installation.Spec.Blueprint.Inline = &lsv1alpha1.InlineBlueprint{
+			Filesystem: filesystem,
+		}
+	}
+
+	builder := blueprints.NewInstallationBuilder(blueprint)
+	for _, entry := range o.imports {
+		name, value, _ := strings.Cut(entry, "=")
+		builder.SetImportValue(name, value)
+	}
+	for _, entry := range o.targets {
+		name, target, _ := strings.Cut(entry, "=")
+		builder.SetImportTarget(name, target)
+	}
+	if err := builder.Build(installation); err != nil {
+		return err
+	}
+
+	manifest, err := builder.Marshal(installation)
+	if err != nil {
+		return fmt.Errorf("cannot marshal installation: %w", err)
+	}
+	cmd.Print(string(manifest))
+	return nil
+}
+
+// parseComponentReference parses a component reference of the form REPOSITORY_URL//COMPONENT_NAME:VERSION.
+func parseComponentReference(ref string) (*lsv1alpha1.ComponentDescriptorReference, error) {
+	repositoryURL, nameAndVersion, found := strings.Cut(ref, "//")
+	if !found {
+		return nil, fmt.Errorf("invalid component reference %q: expected REPOSITORY_URL//COMPONENT_NAME:VERSION", ref)
+	}
+	index := strings.LastIndex(nameAndVersion, ":")
+	if repositoryURL == "" || index <= 0 || index == len(nameAndVersion)-1 {
+		return nil, fmt.Errorf("invalid component reference %q: expected REPOSITORY_URL//COMPONENT_NAME:VERSION", ref)
+	}
+
+	repositoryContext, err := cdv2.NewUnstructured(cdv2.NewOCIRegistryRepository(repositoryURL, ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository %s: %w", repositoryURL, err)
+	}
+	return &lsv1alpha1.ComponentDescriptorReference{
+		RepositoryContext: &repositoryContext,
+		ComponentName:     nameAndVersion[:index],
+		Version:           nameAndVersion[index+1:],
+	}, nil
+}
+
+func (o *createOptions) validateArgs(args []string) error {
+	o.installationName = args[0]
+
+	if o.blueprintDir == "" {
+		if o.component != "" {
+			return fmt.Errorf("the blueprint directory must be specified with --blueprint-dir also together with --component, " +
+				"because the blueprint is not downloaded from the component repository")
+		}
+		return fmt.Errorf("the blueprint directory must be specified with --blueprint-dir")
+	}
+	if (o.component == "") != (o.resourceName == "") {
+		return fmt.Errorf("--component and --resource-name must be specified together")
+	}
+
+	for _, entry := range o.imports {
+		if name, _, found := strings.Cut(entry, "="); !found || name == "" {
+			return fmt.Errorf("invalid --import %q: expected name=value", entry)
+		}
+	}
+	for _, entry := range o.targets {
+		if name, _, found := strings.Cut(entry, "="); !found || name == "" {
+			return fmt.Errorf("invalid --target %q: expected name=target", entry)
+		}
+	}
+	return nil
+}
+
+func (o *createOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation.")
+	fs.StringVar(&o.blueprintDir, "blueprint-dir", "", "path to the blueprint directory, which contains the blueprint.yaml file. Also required with --component, because the imports and exports are read from the local copy of the blueprint.")
+	fs.StringVar(&o.component, "component", "", "reference of the component version with the blueprint resource, given as REPOSITORY_URL//COMPONENT_NAME:VERSION.")
+	fs.StringVar(&o.resourceName, "resource-name", "", "name of the blueprint resource in the component version.")
+	fs.StringArrayVar(&o.imports, "import", nil, "value of a data import, given as name=value. The value is parsed as yaml. Can be repeated.")
+	fs.StringArrayVar(&o.targets, "target", nil, "target of a target import, given as name=target. For targetList imports, the targets are separated by commas, for targetMap imports given as key:target pairs separated by commas. Can be repeated.")
+}
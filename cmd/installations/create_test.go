@@ -0,0 +1,20 @@
+package installations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseComponentReference(t *testing.T) {
+	ref, err := parseComponentReference("example.com/components//github.com/acme/app:v1.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "github.com/acme/app", ref.ComponentName)
+	assert.Equal(t, "v1.0.0", ref.Version)
+	assert.JSONEq(t, `{"type": "ociRegistry", "baseUrl": "example.com/components", "componentNameMapping": "urlPath"}`, string(ref.RepositoryContext.Raw))
+
+	for _, invalid := range []string{"github.com/acme/app:v1.0.0", "//github.com/acme/app:v1.0.0", "example.com//github.com/acme/app", "example.com//app:"} {
+		_, err := parseComponentReference(invalid)
+		assert.ErrorContains(t, err, "expected REPOSITORY_URL//COMPONENT_NAME:VERSION", invalid)
+	}
+}
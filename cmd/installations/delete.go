@@ -0,0 +1,213 @@
+package installations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/pkg/landscaper/installations"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+type deleteOptions struct {
+	kubeconfig       string
+	installationName string
+	namespace        string
+
+	wait     bool
+	timeout  time.Duration
+	interval time.Duration
+}
+
+func NewDeleteCommand(ctx context.Context) *cobra.Command {
+	opts := &deleteOptions{}
+	cmd := &cobra.Command{
+		Use:     "delete [installation-name] [--wait] [--timeout timeout] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.ExactArgs(1),
+		Example: "landscaper-cli installations delete MY_INSTALLATION --wait --namespace MY_NAMESPACE",
+		Short: "Deletes a root installation, so that the landscaper uninstalls the deployed software. With --wait, the " +
+			"deletion progress of the subinstallations, executions and deployItems is printed until the installation is " +
+			"gone. If the deletion fails or does not finish within the timeout, the installation can be force-deleted.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *deleteOptions) run(ctx context.Context, cmd *cobra.Command) error {
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	inst := &lsv1alpha1.Installation{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: o.namespace, Name: o.installationName}, inst); err != nil {
+		return fmt.Errorf("failed to read installation %s: %w", o.installationName, err)
+	}
+	if !installations.IsRootInstallation(inst) {
+		return fmt.Errorf("installation %s is not a root installation: the command is only supported for root installations", o.installationName)
+	}
+
+	if err := k8sClient.Delete(ctx, inst); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete installation %s: %w", o.installationName, err)
+	}
+
+	if !o.wait {
+		cmd.Println("The installation was deleted, the landscaper uninstalls the deployed software in the background")
+		return nil
+	}
+
+	deleted, err := o.waitForDeletion(ctx, cmd, k8sClient)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		cmd.Println("The installation has been deleted")
+		return nil
+	}
+	return o.offerForceDelete(ctx, cmd)
+}
+
+// waitForDeletion prints the phase changes of the installations, executions and deployItems of the installation
+// tree until the installation is gone. The last error of objects in phase DeleteFailed is printed as well. It returns
+// false if the deletion of the installation has failed or has not finished within the timeout.
+func (o *deleteOptions) waitForDeletion(ctx context.Context, cmd *cobra.Command, k8sClient client.Client) (bool, error) {
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+	}
+
+	lastPhases := []inspect.ObjectPhase{}
+	deleted := false
+	failed := false
+	err := wait.PollUntilContextTimeout(ctx, o.interval, o.timeout, true, func(ctx context.Context) (done bool, err error) {
+		currentPhases := []inspect.ObjectPhase{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: o.namespace, Name: o.installationName}, &lsv1alpha1.Installation{}); apierrors.IsNotFound(err) {
+			deleted = true
+		} else {
+			installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+			if err != nil {
+				cmd.Printf("- cannot read installation %s - will retry\n", o.installationName)
+				return false, nil
+			}
+			currentPhases = installationTrees[0].ObjectPhases()
+
+			// the deletion of the installation has finished without success, the landscaper does not retry it
+			inst := installationTrees[0].Installation
+			failed = inst.Status.InstallationPhase == lsv1alpha1.InstallationPhases.DeleteFailed && inst.Status.JobID == inst.Status.JobIDFinished
+		}
+
+		printDeletionProgress(cmd, lastPhases, currentPhases)
+		lastPhases = currentPhases
+		return deleted || failed, nil
+	})
+
+	if deleted {
+		return true, nil
+	}
+	if failed {
+		cmd.Printf("The deletion of installation %s has failed\n", o.installationName)
+		return false, nil
+	}
+	if wait.Interrupted(err) {
+		cmd.Printf("The installation %s has not been deleted within %s\n", o.installationName, o.timeout)
+		return false, nil
+	}
+	return false, err
+}
+
+// printDeletionProgress prints the objects whose phase has changed, together with the last error of objects in phase
+// DeleteFailed, and the objects which are gone.
+func printDeletionProgress(cmd *cobra.Command, lastPhases, currentPhases []inspect.ObjectPhase) {
+	objectKey := func(objectPhase inspect.ObjectPhase) string {
+		return objectPhase.Kind + "/" + objectPhase.Namespace + "/" + objectPhase.Name
+	}
+	now := time.Now().Format(time.TimeOnly)
+
+	last := map[string]string{}
+	for _, objectPhase := range lastPhases {
+		last[objectKey(objectPhase)] = objectPhase.Phase
+	}
+	current := map[string]bool{}
+	for _, objectPhase := range currentPhases {
+		current[objectKey(objectPhase)] = true
+		if phase, ok := last[objectKey(objectPhase)]; ok && phase == objectPhase.Phase {
+			continue
+		}
+		cmd.Printf("%s %s %s/%s is in phase %s\n", now, objectPhase.Kind, objectPhase.Namespace, objectPhase.Name, objectPhase.Phase)
+		if objectPhase.Phase == string(lsv1alpha1.DeployItemPhases.DeleteFailed) && objectPhase.LastError != "" {
+			cmd.Printf("   error: %s\n", objectPhase.LastError)
+		}
+	}
+	for _, objectPhase := range lastPhases {
+		if !current[objectKey(objectPhase)] {
+			cmd.Printf("%s %s %s/%s is gone\n", now, objectPhase.Kind, objectPhase.Namespace, objectPhase.Name)
+		}
+	}
+}
+
+// offerForceDelete falls back to force-deleting the installation. The force-delete prints the objects to be deleted
+// and only proceeds if this is confirmed.
+func (o *deleteOptions) offerForceDelete(ctx context.Context, cmd *cobra.Command) error {
+	cmd.Println("The installation can be force-deleted. Concerning the deployed software no guarantees could be given if it is uninstalled or not.")
+	forceDelete := &forceDeleteOptions{
+		kubeconfig:        o.kubeconfig,
+		installationNames: []string{o.installationName},
+		namespace:         o.namespace,
+		workers:           1,
+	}
+	return forceDelete.run(ctx, cmd, logger.Log)
+}
+
+func (o *deleteOptions) validateArgs(args []string) error {
+	o.installationName = args[0]
+
+	if o.wait {
+		if o.timeout <= 0 {
+			return fmt.Errorf("the timeout must be positive")
+		}
+		if o.interval <= 0 {
+			return fmt.Errorf("the interval must be positive")
+		}
+	}
+	return nil
+}
+
+func (o *deleteOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+	fs.BoolVar(&o.wait, "wait", false, "wait until the installation is gone, and print the deletion progress of its subinstallations, executions and deployItems.")
+	fs.DurationVar(&o.timeout, "timeout", 15*time.Minute, "together with --wait, the maximal time to wait. Afterwards the installation can be force-deleted.")
+	fs.DurationVar(&o.interval, "interval", 5*time.Second, "together with --wait, the time between two checks of the installation.")
+}
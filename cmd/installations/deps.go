@@ -0,0 +1,230 @@
+package installations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+type depsOptions struct {
+	kubeconfig       string
+	installationName string
+	namespace        string
+	omode            string
+}
+
+func NewDepsCommand(ctx context.Context) *cobra.Command {
+	opts := &depsOptions{}
+	cmd := &cobra.Command{
+		Use:     "deps [installation-name] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Aliases: []string{"dependencies"},
+		Args:    cobra.ExactArgs(1),
+		Example: "landscaper-cli installations deps MY_INSTALLATION --namespace MY_NAMESPACE",
+		Short: "Displays the data flow between the subinstallations of an installation, i.e. which sibling exports the " +
+			"DataObjects and Targets imported by a subinstallation. It prints the subinstallations in dependency order " +
+			"and highlights unsatisfied imports, cycles and the producers a subinstallation is currently waiting for.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd, logger.Log); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *depsOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+	if err != nil {
+		return fmt.Errorf("cannot collect installation: %w", err)
+	}
+
+	deps := inspect.ComputeDependencies(installationTrees[0])
+
+	switch o.omode {
+	case OUTPUT_YAML:
+		marshaledDeps, err := yaml.Marshal(deps)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to yaml: %w", err)
+		}
+		cmd.Print(string(marshaledDeps))
+	case OUTPUT_JSON:
+		marshaledDeps, err := json.Marshal(deps)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to json: %w", err)
+		}
+		cmd.Print(string(marshaledDeps))
+	default:
+		if len(deps.Subinstallations) == 0 {
+			cmd.Printf("Installation %s has no subinstallations\n", o.installationName)
+			return nil
+		}
+		output, err := formatDependencies(deps)
+		if err != nil {
+			return err
+		}
+		cmd.Print(output)
+	}
+
+	return nil
+}
+
+// formatDependencies prints the dependency order, one line per import of a subinstallation with its producers, and
+// the problems found in the dependency graph.
+func formatDependencies(deps *inspect.Dependencies) (string, error) {
+	out := strings.Builder{}
+
+	phases := map[string]string{}
+	for _, subDeps := range deps.Subinstallations {
+		phases[subDeps.Name] = formatDependencyPhase(subDeps.Phase)
+	}
+
+	fmt.Fprintf(&out, "Order of the subinstallations of %s:\n", deps.Installation)
+	ordered := 0
+	for i, step := range deps.Order {
+		names := make([]string, 0, len(step))
+		for _, name := range step {
+			names = append(names, fmt.Sprintf("%s [%s]", name, phases[name]))
+		}
+		ordered += len(step)
+		fmt.Fprintf(&out, "  %d. %s\n", i+1, strings.Join(names, ", "))
+	}
+	if ordered < len(deps.Subinstallations) {
+		out.WriteString("  not ordered because they are part of or depend on a cycle:")
+		orderedNames := map[string]bool{}
+		for _, step := range deps.Order {
+			for _, name := range step {
+				orderedNames[name] = true
+			}
+		}
+		for _, subDeps := range deps.Subinstallations {
+			if !orderedNames[subDeps.Name] {
+				fmt.Fprintf(&out, " %s", subDeps.Name)
+			}
+		}
+		out.WriteString("\n")
+	}
+	out.WriteString("\n")
+
+	w := tabwriter.NewWriter(&out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "SUBINSTALLATION\tIMPORT\tTYPE\tREF\tPROVIDED BY")
+	for _, subDeps := range deps.Subinstallations {
+		for _, imp := range subDeps.Imports {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", subDeps.Name, imp.Name, imp.Type, imp.Ref, formatImportSource(deps, imp))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	out.WriteString("\n")
+
+	unsatisfied := deps.Unsatisfied()
+	if len(unsatisfied) == 0 {
+		out.WriteString("Unsatisfied imports: none\n")
+	} else {
+		fmt.Fprintf(&out, "UNSATISFIED IMPORTS: %s\n", strings.Join(unsatisfied, ", "))
+	}
+
+	if len(deps.Cycles) == 0 {
+		out.WriteString("Cycles: none\n")
+	} else {
+		for _, cycle := range deps.Cycles {
+			fmt.Fprintf(&out, "CYCLE: %s\n", strings.Join(cycle, " -> "))
+		}
+	}
+
+	blocked := false
+	for _, subDeps := range deps.Subinstallations {
+		if len(subDeps.BlockedBy) == 0 {
+			continue
+		}
+		blocked = true
+		fmt.Fprintf(&out, "BLOCKED: %s [%s] is waiting for %s\n", subDeps.Name, phases[subDeps.Name], strings.Join(subDeps.BlockedBy, ", "))
+	}
+	if !blocked {
+		out.WriteString("Blocked subinstallations: none\n")
+	}
+
+	return out.String(), nil
+}
+
+func formatImportSource(deps *inspect.Dependencies, imp *inspect.DependencyImport) string {
+	switch imp.Source {
+	case inspect.ImportSourceParent:
+		return fmt.Sprintf("parent %s", deps.Installation)
+	case inspect.ImportSourceUnsatisfied:
+		return "UNSATISFIED"
+	}
+
+	producers := make([]string, 0, len(imp.Producers))
+	for _, producer := range imp.Producers {
+		p := fmt.Sprintf("%s [%s]", producer.Name, formatDependencyPhase(producer.Phase))
+		if producer.Blocking {
+			p += " BLOCKING: " + producer.Reason
+		}
+		producers = append(producers, p)
+	}
+	return strings.Join(producers, ", ")
+}
+
+func formatDependencyPhase(phase string) string {
+	if len(phase) == 0 {
+		return "no phase"
+	}
+	return phase
+}
+
+func (o *depsOptions) validateArgs(args []string) error {
+	o.installationName = args[0]
+
+	switch o.omode {
+	case inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid option for '--output'/'-o' flag: %q", o.omode)
+	}
+}
+
+func (o *depsOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+	fs.StringVarP(&o.omode, "output", "o", inspect.OutputTable, fmt.Sprintf("how the output is formatted. Valid values are %s, %s, and %s.", inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON))
+}
@@ -0,0 +1,170 @@
+package installations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+type explainFailureOptions struct {
+	kubeconfig       string
+	installationName string
+	namespace        string
+}
+
+func NewExplainFailureCommand(ctx context.Context) *cobra.Command {
+	opts := &explainFailureOptions{}
+	cmd := &cobra.Command{
+		Use:     "explain-failure [installation-name] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Aliases: []string{"explain"},
+		Args:    cobra.ExactArgs(1),
+		Example: "landscaper-cli installations explain-failure MY_INSTALLATION --namespace MY_NAMESPACE",
+		Short: "Follows the failed branches of an installation down to the deepest failed objects, usually deployItems, " +
+			"and prints their errors, error history and events together with a suggested next action.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd, logger.Log); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *explainFailureOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+	if err != nil {
+		return fmt.Errorf("cannot collect installation: %w", err)
+	}
+
+	explanations := inspect.ExplainFailures(installationTrees[0])
+	if len(explanations) == 0 {
+		cmd.Printf("Installation %s has no failed objects\n", o.installationName)
+		return nil
+	}
+
+	if err := collector.CollectEvents(ctx, explanations); err != nil {
+		return fmt.Errorf("cannot collect events: %w", err)
+	}
+
+	for i, explanation := range explanations {
+		if i > 0 {
+			cmd.Println()
+		}
+		cmd.Print(formatFailureExplanation(explanation))
+	}
+
+	return nil
+}
+
+func formatFailureExplanation(explanation *inspect.FailureExplanation) string {
+	out := strings.Builder{}
+
+	path := make([]string, 0, len(explanation.Path))
+	for _, obj := range explanation.Path {
+		path = append(path, fmt.Sprintf("%s %s [%s]", inspect.KindOf(obj), obj.GetName(), inspect.PhaseOf(obj)))
+	}
+	fmt.Fprintf(&out, "Failed branch: %s\n", strings.Join(path, " -> "))
+
+	cause := explanation.Cause()
+	fmt.Fprintf(&out, "Root cause: %s %s/%s\n", inspect.KindOf(cause), cause.GetNamespace(), cause.GetName())
+	if deployItem, ok := cause.(*lsv1alpha1.DeployItem); ok {
+		fmt.Fprintf(&out, "  Type: %s\n", deployItem.Spec.Type)
+	}
+
+	if explanation.LastError == nil {
+		out.WriteString("  Last error: none\n")
+	} else {
+		out.WriteString(formatLsError("Last error", explanation.LastError))
+	}
+	if explanation.FirstError != nil {
+		out.WriteString(formatLsError("First error", explanation.FirstError))
+	}
+	if len(explanation.ErrorHistory) > 0 {
+		out.WriteString("  Error history:\n")
+		for _, e := range explanation.ErrorHistory {
+			fmt.Fprintf(&out, "    - %s %s: %s\n", formatTime(e.LastUpdateTime.Time), e.Reason, e.Message)
+		}
+	}
+
+	if len(explanation.Events) > 0 {
+		out.WriteString("  Events:\n")
+		for _, event := range explanation.Events {
+			fmt.Fprintf(&out, "    - %s %s %s/%s %s: %s\n", formatTime(event.LastTimestamp.Time), event.Type,
+				event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, event.Message)
+		}
+	}
+
+	fmt.Fprintf(&out, "Suggested next action: %s\n", explanation.Suggestion)
+	return out.String()
+}
+
+func formatLsError(title string, lsError *lsv1alpha1.Error) string {
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "  %s: %s\n", title, lsError.Message)
+	fmt.Fprintf(&out, "    Operation: %s\n", lsError.Operation)
+	fmt.Fprintf(&out, "    Reason: %s\n", lsError.Reason)
+	if len(lsError.Codes) > 0 {
+		codes := make([]string, 0, len(lsError.Codes))
+		for _, code := range lsError.Codes {
+			codes = append(codes, string(code))
+		}
+		fmt.Fprintf(&out, "    Codes: %s\n", strings.Join(codes, ", "))
+	}
+	fmt.Fprintf(&out, "    Time: %s\n", formatTime(lsError.LastUpdateTime.Time))
+	return out.String()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func (o *explainFailureOptions) validateArgs(args []string) error {
+	o.installationName = args[0]
+	return nil
+}
+
+func (o *explainFailureOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+}
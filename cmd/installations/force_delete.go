@@ -1,19 +1,25 @@
 package installations
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
 
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/gardener/landscapercli/pkg/logger"
@@ -21,22 +27,69 @@ import (
 )
 
 type forceDeleteOptions struct {
-	kubeconfig       string
-	installationName string
-	namespace        string
-	k8sClient        client.Client
+	kubeconfig        string
+	installationNames []string
+	namespace         string
+	all               bool
+	selector          string
+	workers           int
+	k8sClient         client.Client
+
+	dryRun              bool
+	yes                 bool
+	keepDeployedWarning bool
+	purgeDeployed       bool
+}
+
+// forceDeleteAction is what a step of force-delete does with an object.
+type forceDeleteAction int
+
+const (
+	deleteAction forceDeleteAction = iota
+	purgeDeployedAction
+	removeFinalizerAction
+)
+
+// forceDeleteStep is a step of the plan of force-delete: an object is deleted, the resources deployed by a deployItem
+// are purged from the target cluster, or the finalizers of an object are removed.
+type forceDeleteStep struct {
+	action     forceDeleteAction
+	object     client.Object
+	objectType string
+}
+
+func (s forceDeleteStep) String() string {
+	action := "delete"
+	switch s.action {
+	case purgeDeployedAction:
+		action = "purge deployed resources of"
+	case removeFinalizerAction:
+		action = "remove finalizers from"
+	}
+	return fmt.Sprintf("%s %s %s/%s", action, s.objectType, s.object.GetNamespace(), s.object.GetName())
+}
+
+// forceDeleteResult is the result of force-deleting an object. Err is set if the object could not be deleted or its
+// finalizers could not be removed. Messages are the progress messages of the steps on the object, which are printed
+// after all plans have been executed, because the plans are executed concurrently.
+type forceDeleteResult struct {
+	object     client.Object
+	objectType string
+	messages   []string
+	err        error
 }
 
 func NewForceDeleteCommand(ctx context.Context) *cobra.Command {
 	opts := &forceDeleteOptions{}
 	cmd := &cobra.Command{
-		Use:     "force-delete [installation-name] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Use:     "force-delete [installation-name...] [--all | --selector selector] [--namespace namespace] [--dry-run] [--yes] [--kubeconfig kubeconfig.yaml]",
 		Aliases: []string{"fd"},
-		Args:    cobra.MaximumNArgs(1),
-		Example: "landscaper-cli installations force-delete",
-		Short: "Deletes an installations and the depending executions and deployItems in cluster and namespace of the " +
-			"current kubectl cluster context. Concerning the deployed software no guarantees could be given if it is " +
-			"uninstalled or not.",
+		Args:    cobra.ArbitraryArgs,
+		Example: "landscaper-cli installations force-delete MY_INSTALLATION --namespace MY_NAMESPACE --dry-run",
+		Short: "Deletes installations and the depending executions and deployItems in cluster and namespace of the " +
+			"current kubectl cluster context. The installations are given by name, by label selector or with --all. " +
+			"Concerning the deployed software no guarantees could be given if it is uninstalled or not. The objects to " +
+			"be deleted are shown and have to be confirmed, unless --yes is set.",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := opts.validateArgs(args); err != nil {
 				cmd.PrintErr(err.Error())
@@ -47,7 +100,6 @@ func NewForceDeleteCommand(ctx context.Context) *cobra.Command {
 				cmd.PrintErr(err.Error())
 				os.Exit(1)
 			}
-			cmd.Println("All objects deleted")
 		},
 	}
 
@@ -73,81 +125,358 @@ func (o *forceDeleteOptions) run(ctx context.Context, cmd *cobra.Command, log lo
 		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
 	}
 
-	if o.installationName == "" {
-		return fmt.Errorf("installationName was not defined.")
+	installationTrees, missingResults, err := o.collectInstallationTrees(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	for _, result := range missingResults {
+		cmd.Printf("- %s %s/%s not found, skipping it\n", result.objectType, result.object.GetNamespace(), result.object.GetName())
+	}
+	if len(installationTrees) == 0 {
+		cmd.Println("No installations found")
+		if len(missingResults) > 0 {
+			return fmt.Errorf("%d installations not found", len(missingResults))
+		}
+		return nil
+	}
+
+	// each installation tree has its own plan, so that independent trees can be deleted concurrently
+	plans := make([][]forceDeleteStep, len(installationTrees))
+	plan := []forceDeleteStep{}
+	for i, installationTree := range installationTrees {
+		plans[i] = o.planInstallationTrees(nil, []*inspect.InstallationTree{installationTree})
+		plan = append(plan, plans[i]...)
+	}
+
+	if o.keepDeployedWarning {
+		warning, err := formatDeployedWarning(plan)
+		if err != nil {
+			return err
+		}
+		cmd.Print(warning)
+	}
+
+	if o.dryRun {
+		cmd.Print(formatForceDeletePlan(plan))
+		return nil
+	}
+
+	if !o.yes {
+		cmd.Print(formatForceDeletePlan(plan))
+		if !confirm(cmd, "Do you want to delete these objects and remove their finalizers? [y/N] ") {
+			return fmt.Errorf("force-delete aborted, no objects were deleted")
+		}
+	}
+
+	results := append(missingResults, o.executePlans(ctx, plans)...)
+	for _, result := range results {
+		for _, message := range result.messages {
+			cmd.Println(message)
+		}
+	}
+	summary, err := formatForceDeleteResults(results)
+	if err != nil {
+		return err
 	}
+	cmd.Print(summary)
+
+	failed := strings.Builder{}
+	failedCount := 0
+	for _, result := range results {
+		if result.err != nil {
+			failedCount++
+			fmt.Fprintf(&failed, "- %s %s/%s: %s\n", result.objectType, result.object.GetNamespace(), result.object.GetName(), result.err.Error())
+		}
+	}
+	if failedCount > 0 {
+		return fmt.Errorf("%d objects could not be deleted or their finalizers could not be removed:\n%s", failedCount, failed.String())
+	}
+	cmd.Println("All objects deleted")
+	return nil
+}
 
+// collectInstallationTrees returns the disjoint installation trees of the named installations, of the installations
+// matching the selector, or of all root installations of the namespace. Named installations that do not exist are
+// returned as results with a not found error.
+func (o *forceDeleteOptions) collectInstallationTrees(ctx context.Context, cmd *cobra.Command) ([]*inspect.InstallationTree, []forceDeleteResult, error) {
 	collector := inspect.Collector{
-		K8sClient: k8sClient,
+		K8sClient: o.k8sClient,
+		Progress:  cmd.ErrOrStderr(),
 	}
-	installationTrees, err := collector.CollectInstallationsInCluster(o.installationName, o.namespace)
+
+	if len(o.installationNames) > 0 {
+		installationTrees, missing, err := collector.CollectNamedInstallations(ctx, o.installationNames, o.namespace)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot collect installations etc.: %w", err)
+		}
+		missingResults := []forceDeleteResult{}
+		for _, name := range missing {
+			inst := &lsv1alpha1.Installation{}
+			inst.Name = name
+			inst.Namespace = o.namespace
+			err := apierrors.NewNotFound(lsv1alpha1.SchemeGroupVersion.WithResource("installations").GroupResource(), name)
+			missingResults = append(missingResults, forceDeleteResult{object: inst, objectType: "installation", err: err})
+		}
+		return installationTrees, missingResults, nil
+	}
+
+	installationTrees, err := collector.CollectInstallationsInCluster(ctx, "", o.namespace)
 	if err != nil {
-		return fmt.Errorf("cannot collect installations etc.: %w", err)
+		return nil, nil, fmt.Errorf("cannot collect installations etc.: %w", err)
+	}
+	if o.selector == "" {
+		return installationTrees, nil, nil
 	}
 
-	return o.deleteInstallationTrees(ctx, installationTrees)
+	selector, err := labels.Parse(o.selector)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid label selector %q: %w", o.selector, err)
+	}
+	return inspect.SelectInstallations(installationTrees, func(inst *lsv1alpha1.Installation) bool {
+		return selector.Matches(labels.Set(inst.Labels))
+	}), nil, nil
 }
 
-func (o *forceDeleteOptions) deleteInstallationTrees(ctx context.Context, installationTrees []*inspect.InstallationTree) error {
+// planInstallationTrees appends the steps to delete the installation trees to the plan. Installations and executions
+// are deleted first, so that the landscaper does not recreate their children, and their finalizers are removed after
+// their children are gone. With --purge-deployed, the deployed resources of a deployItem are purged before its
+// finalizers are removed.
+func (o *forceDeleteOptions) planInstallationTrees(plan []forceDeleteStep, installationTrees []*inspect.InstallationTree) []forceDeleteStep {
 	for _, installationTree := range installationTrees {
-		if err := o.deleteObject(ctx, installationTree.Installation, "installation"); err != nil {
-			return err
+		plan = append(plan, forceDeleteStep{action: deleteAction, object: installationTree.Installation, objectType: "installation"})
+		plan = o.planInstallationTrees(plan, installationTree.SubInstallations)
+		plan = o.planExecutionTree(plan, installationTree.Execution)
+		plan = append(plan, forceDeleteStep{action: removeFinalizerAction, object: installationTree.Installation, objectType: "installation"})
+	}
+	return plan
+}
+
+func (o *forceDeleteOptions) planExecutionTree(plan []forceDeleteStep, executionTree *inspect.ExecutionTree) []forceDeleteStep {
+	if executionTree == nil || executionTree.Execution == nil {
+		return plan
+	}
+
+	plan = append(plan, forceDeleteStep{action: deleteAction, object: executionTree.Execution, objectType: "execution"})
+	for _, di := range executionTree.DeployItems {
+		plan = append(plan, forceDeleteStep{action: deleteAction, object: di.DeployItem, objectType: "deployItem"})
+		if o.purgeDeployed && inspect.CanPurge(di.DeployItem) {
+			plan = append(plan, forceDeleteStep{action: purgeDeployedAction, object: di.DeployItem, objectType: "deployItem"})
 		}
+		plan = append(plan, forceDeleteStep{action: removeFinalizerAction, object: di.DeployItem, objectType: "deployItem"})
+	}
+	return append(plan, forceDeleteStep{action: removeFinalizerAction, object: executionTree.Execution, objectType: "execution"})
+}
 
-		if err := o.deleteInstallationTrees(ctx, installationTree.SubInstallations); err != nil {
-			return err
+// executePlans executes the plans concurrently with the configured number of workers. The results are returned in
+// the order of the plans.
+func (o *forceDeleteOptions) executePlans(ctx context.Context, plans [][]forceDeleteStep) []forceDeleteResult {
+	planResults := make([][]forceDeleteResult, len(plans))
+	indexes := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < o.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				planResults[i] = o.executePlan(ctx, plans[i])
+			}
+		}()
+	}
+	for i := range plans {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	results := []forceDeleteResult{}
+	for _, planResult := range planResults {
+		results = append(results, planResult...)
+	}
+	return results
+}
+
+// executePlan executes the steps of the plan and returns a result for each object. The plan is continued if an
+// object fails, but the finalizers of an object are not removed if it could not be deleted or its deployed resources
+// could not be purged.
+func (o *forceDeleteOptions) executePlan(ctx context.Context, plan []forceDeleteStep) []forceDeleteResult {
+	results := []forceDeleteResult{}
+	failed := map[client.Object]bool{}
+	messages := map[client.Object][]string{}
+	for _, step := range plan {
+		if failed[step.object] {
+			continue
 		}
 
-		if err := o.deleteExecutionTree(ctx, installationTree.Execution); err != nil {
-			return err
+		var stepMessages []string
+		var err error
+		switch step.action {
+		case deleteAction:
+			stepMessages, err = o.deleteObject(ctx, step.object, step.objectType)
+		case purgeDeployedAction:
+			stepMessages, err = o.purgeDeployedResources(ctx, step.object.(*lsv1alpha1.DeployItem))
+		case removeFinalizerAction:
+			stepMessages, err = o.removeFinalizer(ctx, step.object, step.objectType)
 		}
+		messages[step.object] = append(messages[step.object], stepMessages...)
 
-		if err := o.removeFinalizer(ctx, installationTree.Installation, "installation"); err != nil {
-			return err
+		if err != nil {
+			failed[step.object] = true
+			results = append(results, forceDeleteResult{object: step.object, objectType: step.objectType, messages: messages[step.object], err: err})
+		} else if step.action == removeFinalizerAction {
+			results = append(results, forceDeleteResult{object: step.object, objectType: step.objectType, messages: messages[step.object]})
 		}
 	}
-	return nil
+	return results
 }
 
-func (o *forceDeleteOptions) deleteExecutionTree(ctx context.Context, executionTree *inspect.ExecutionTree) error {
-	if executionTree == nil || executionTree.Execution == nil {
-		return nil
+// purgeDeployedResources deletes the resources deployed by the deployItem from its target cluster and returns a message
+// with the result for each resource.
+func (o *forceDeleteOptions) purgeDeployedResources(ctx context.Context, deployItem *lsv1alpha1.DeployItem) ([]string, error) {
+	collector := inspect.Collector{K8sClient: o.k8sClient}
+	purged, err := collector.PurgeDeployed(ctx, deployItem, nil)
+
+	messages := []string{}
+	failedCount := 0
+	for _, resource := range purged {
+		ref := resource.Reference
+		name := ref.Name
+		if ref.Namespace != "" {
+			name = fmt.Sprintf("%s/%s", ref.Namespace, ref.Name)
+		}
+		switch resource.Result {
+		case inspect.PurgeDeleted:
+			messages = append(messages, fmt.Sprintf("- purged %s %s of deployItem %s", ref.Kind, name, deployItem.Name))
+		case inspect.PurgeAlreadyGone:
+			messages = append(messages, fmt.Sprintf("- already gone: %s %s of deployItem %s", ref.Kind, name, deployItem.Name))
+		case inspect.PurgeKept:
+			messages = append(messages, fmt.Sprintf("- kept %s %s of deployItem %s due to its manage policy", ref.Kind, name, deployItem.Name))
+		default:
+			failedCount++
+			messages = append(messages, fmt.Sprintf("- cannot purge %s %s of deployItem %s: %s", ref.Kind, name, deployItem.Name, resource.Error))
+		}
 	}
 
-	if err := o.deleteObject(ctx, executionTree.Execution, "execution"); err != nil {
-		return err
+	if err != nil {
+		return messages, fmt.Errorf("cannot purge deployed resources of deployItem %s: %w", deployItem.Name, err)
+	}
+	if len(purged) == 0 {
+		messages = append(messages, fmt.Sprintf("- no deployed resources known for deployItem %s", deployItem.Name))
 	}
+	if failedCount > 0 {
+		return messages, fmt.Errorf("cannot purge %d deployed resources of deployItem %s", failedCount, deployItem.Name)
+	}
+	return messages, nil
+}
 
-	for _, di := range executionTree.DeployItems {
-		if err := o.deleteObject(ctx, di.DeployItem, "deployItem"); err != nil {
-			return err
+// formatForceDeleteResults prints a table with the result for each object.
+func formatForceDeleteResults(results []forceDeleteResult) (string, error) {
+	out := strings.Builder{}
+	w := tabwriter.NewWriter(&out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "RESULT\tTYPE\tNAMESPACE\tNAME")
+	for _, result := range results {
+		status := "deleted"
+		if apierrors.IsNotFound(result.err) {
+			status = "not found"
+		} else if result.err != nil {
+			status = "failed"
 		}
-		if err := o.removeFinalizer(ctx, di.DeployItem, "deployItem"); err != nil {
-			return err
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status, result.objectType, result.object.GetNamespace(), result.object.GetName())
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func formatForceDeletePlan(plan []forceDeleteStep) string {
+	out := strings.Builder{}
+	out.WriteString("The following steps will be executed in this order:\n")
+	for i, step := range plan {
+		fmt.Fprintf(&out, "%4d. %s\n", i+1, step)
+	}
+	return out.String()
+}
+
+// formatDeployedWarning lists the deployItems of the plan whose deployed resources remain in the target clusters,
+// because force-delete removes the finalizers before the deployers can uninstall them. DeployItems whose resources are
+// purged are omitted.
+func formatDeployedWarning(plan []forceDeleteStep) (string, error) {
+	purged := map[client.Object]bool{}
+	for _, step := range plan {
+		if step.action == purgeDeployedAction {
+			purged[step.object] = true
 		}
 	}
 
-	if err := o.removeFinalizer(ctx, executionTree.Execution, "execution"); err != nil {
-		return err
+	out := strings.Builder{}
+	count := 0
+	for _, step := range plan {
+		deployItem, ok := step.object.(*lsv1alpha1.DeployItem)
+		if !ok || step.action != deleteAction || purged[step.object] {
+			continue
+		}
+		count++
+
+		target := "none"
+		if deployItem.Spec.Target != nil {
+			target = fmt.Sprintf("%s/%s", deployItem.Spec.Target.Namespace, deployItem.Spec.Target.Name)
+		}
+		fmt.Fprintf(&out, "- deployItem %s/%s (type %s, target %s)", deployItem.Namespace, deployItem.Name, deployItem.Spec.Type, target)
+
+		refs, err := inspect.ManagedResourcesOf(deployItem)
+		if err != nil {
+			return "", fmt.Errorf("cannot decode provider status of deployitem %s: %w", deployItem.Name, err)
+		}
+		if len(refs) == 0 {
+			out.WriteString(": deployed resources unknown\n")
+			continue
+		}
+		fmt.Fprintf(&out, ": %d deployed resources\n", len(refs))
+		for _, ref := range refs {
+			if ref.Namespace == "" {
+				fmt.Fprintf(&out, "    %s %s\n", ref.Kind, ref.Name)
+			} else {
+				fmt.Fprintf(&out, "    %s %s/%s\n", ref.Kind, ref.Namespace, ref.Name)
+			}
+		}
 	}
 
-	return nil
+	if count == 0 {
+		return "No deployed resources will remain orphaned in the target clusters\n", nil
+	}
+	return fmt.Sprintf("Warning: the resources deployed by the following %d deployItems will not be uninstalled and remain orphaned in the target clusters:\n%s",
+		count, out.String()), nil
 }
 
-func (o *forceDeleteOptions) deleteObject(ctx context.Context, object client.Object, objectType string) error {
+// confirm prints the question and reads the answer from the input of the command. Only "y" and "yes" confirm.
+func confirm(cmd *cobra.Command, question string) bool {
+	cmd.Print(question)
+	answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && answer == "" {
+		// no answer, e.g. because the input is not a terminal
+		cmd.Println()
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// deleteObject deletes the object and returns the progress messages.
+func (o *forceDeleteOptions) deleteObject(ctx context.Context, object client.Object, objectType string) ([]string, error) {
 	if err := o.k8sClient.Delete(ctx, object); err != nil {
 		if apierrors.IsNotFound(err) {
-			fmt.Printf("- already gone: %s %s\n", objectType, object.GetName())
-			return nil
+			return []string{fmt.Sprintf("- already gone: %s %s", objectType, object.GetName())}, nil
 		}
-		return fmt.Errorf("cannot delete %s %s: %w", objectType, object.GetName(), err)
+		return nil, fmt.Errorf("cannot delete %s %s: %w", objectType, object.GetName(), err)
 	}
 
-	return nil
+	return nil, nil
 }
 
-func (o *forceDeleteOptions) removeFinalizer(ctx context.Context, object client.Object, objectType string) error {
+// removeFinalizer removes the finalizers of the object, retrying for some seconds, and returns the progress messages.
+func (o *forceDeleteOptions) removeFinalizer(ctx context.Context, object client.Object, objectType string) ([]string, error) {
 	var lastErr error = nil
+	messages := []string{}
 
 	if err := wait.PollUntilContextTimeout(ctx, time.Second, 10*time.Second, true, func(ctx context.Context) (done bool, err error) {
 		if err := o.k8sClient.Get(ctx, client.ObjectKeyFromObject(object), object); err != nil {
@@ -155,8 +484,7 @@ func (o *forceDeleteOptions) removeFinalizer(ctx context.Context, object client.
 				return true, nil
 			}
 			lastErr = fmt.Errorf("cannot fetch %s %s: %w", objectType, object.GetName(), err)
-			preliminaryMessage := fmt.Sprintf("- cannot fetch %s %s - will retry", objectType, object.GetName())
-			fmt.Println(preliminaryMessage)
+			messages = append(messages, fmt.Sprintf("- cannot fetch %s %s - will retry", objectType, object.GetName()))
 			return false, nil
 		}
 
@@ -166,29 +494,46 @@ func (o *forceDeleteOptions) removeFinalizer(ctx context.Context, object client.
 				return true, nil
 			}
 			lastErr = fmt.Errorf("cannot remove finalizers from %s %s: %w", objectType, object.GetName(), err)
-			preliminaryMessage := fmt.Sprintf("- cannot remove finalizers from %s %s - will retry", objectType, object.GetName())
-			fmt.Println(preliminaryMessage)
+			messages = append(messages, fmt.Sprintf("- cannot remove finalizers from %s %s - will retry", objectType, object.GetName()))
 			return false, nil
 		}
 
 		return true, nil
 
 	}); err != nil {
-		return lastErr
+		return messages, lastErr
 	}
 
-	fmt.Printf("- deleted %s %s\n", objectType, object.GetName())
-	return nil
+	return append(messages, fmt.Sprintf("- deleted %s %s", objectType, object.GetName())), nil
 }
 
 func (o *forceDeleteOptions) validateArgs(args []string) error {
-	if len(args) == 1 {
-		o.installationName = args[0]
+	o.installationNames = args
+
+	selections := 0
+	for _, set := range []bool{len(args) > 0, o.all, o.selector != ""} {
+		if set {
+			selections++
+		}
+	}
+	if selections != 1 {
+		return fmt.Errorf("specify either installation names, --all, or --selector")
+	}
+
+	if o.workers < 1 {
+		return fmt.Errorf("the number of workers must be at least 1")
 	}
 	return nil
 }
 
 func (o *forceDeleteOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
-	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installations. Required if --kubeconfig is used.")
+	fs.BoolVar(&o.all, "all", false, "delete all root installations of the namespace.")
+	fs.StringVarP(&o.selector, "selector", "l", "", "delete the installations matching the label selector. Subinstallations of matching installations are deleted as part of them.")
+	fs.BoolVar(&o.purgeDeployed, "purge-deployed", false, "delete the resources deployed by helm and manifest deployItems from the target clusters before their finalizers are removed. Helm releases are uninstalled by deleting their resources and release secrets, without running hooks.")
+	fs.IntVar(&o.workers, "workers", 4, "number of installation trees which are deleted concurrently.")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "print the objects which would be deleted and whose finalizers would be removed, in order, without changing anything.")
+	fs.BoolVarP(&o.yes, "yes", "y", false, "delete the objects without asking for confirmation.")
+	fs.BoolVar(&o.keepDeployedWarning, "keep-deployed-warning", false, "print a summary of the deployItems whose deployed resources will not be uninstalled and remain orphaned in the target clusters.")
 }
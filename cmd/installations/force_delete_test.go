@@ -0,0 +1,204 @@
+package installations
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+)
+
+// newForceDeleteTestTree returns the installation root with the subinstallation sub. Both have an execution with a
+// deployItem: the helm deployItem sub-helm, which has deployed a Service, and the container deployItem root-job.
+func newForceDeleteTestTree() *inspect.InstallationTree {
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "test"}
+	}
+	helmItem := &lsv1alpha1.DeployItem{ObjectMeta: meta("sub-helm")}
+	helmItem.Spec.Type = inspect.HelmDeployItemType
+	helmItem.Spec.Target = &lsv1alpha1.ObjectReference{Name: "cluster", Namespace: "test"}
+	helmItem.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(`{"managedResources": [
+		{"resource": {"apiVersion": "v1", "kind": "Service", "name": "ingress", "namespace": "ingress"}}
+	]}`)}
+	containerItem := &lsv1alpha1.DeployItem{ObjectMeta: meta("root-job")}
+	containerItem.Spec.Type = inspect.ContainerDeployItemType
+
+	return &inspect.InstallationTree{
+		Installation: &lsv1alpha1.Installation{ObjectMeta: meta("root")},
+		SubInstallations: []*inspect.InstallationTree{
+			{
+				Installation: &lsv1alpha1.Installation{ObjectMeta: meta("sub")},
+				Execution: &inspect.ExecutionTree{
+					Execution:   &lsv1alpha1.Execution{ObjectMeta: meta("sub")},
+					DeployItems: []*inspect.DeployItemLeaf{{DeployItem: helmItem}},
+				},
+			},
+		},
+		Execution: &inspect.ExecutionTree{
+			Execution:   &lsv1alpha1.Execution{ObjectMeta: meta("root")},
+			DeployItems: []*inspect.DeployItemLeaf{{DeployItem: containerItem}},
+		},
+	}
+}
+
+func TestPlanInstallationTrees(t *testing.T) {
+	tests := []struct {
+		name          string
+		purgeDeployed bool
+		expectedSteps []string
+	}{
+		{
+			name: "Children are deleted before the finalizers of their parents are removed",
+			expectedSteps: []string{
+				"delete installation test/root",
+				"delete installation test/sub",
+				"delete execution test/sub",
+				"delete deployItem test/sub-helm",
+				"remove finalizers from deployItem test/sub-helm",
+				"remove finalizers from execution test/sub",
+				"remove finalizers from installation test/sub",
+				"delete execution test/root",
+				"delete deployItem test/root-job",
+				"remove finalizers from deployItem test/root-job",
+				"remove finalizers from execution test/root",
+				"remove finalizers from installation test/root",
+			},
+		},
+		{
+			name:          "Deployed resources of helm deployItems are purged before their finalizers are removed",
+			purgeDeployed: true,
+			expectedSteps: []string{
+				"delete installation test/root",
+				"delete installation test/sub",
+				"delete execution test/sub",
+				"delete deployItem test/sub-helm",
+				"purge deployed resources of deployItem test/sub-helm",
+				"remove finalizers from deployItem test/sub-helm",
+				"remove finalizers from execution test/sub",
+				"remove finalizers from installation test/sub",
+				"delete execution test/root",
+				"delete deployItem test/root-job",
+				"remove finalizers from deployItem test/root-job",
+				"remove finalizers from execution test/root",
+				"remove finalizers from installation test/root",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &forceDeleteOptions{purgeDeployed: tt.purgeDeployed}
+			plan := o.planInstallationTrees(nil, []*inspect.InstallationTree{newForceDeleteTestTree()})
+
+			steps := []string{}
+			for _, step := range plan {
+				steps = append(steps, step.String())
+			}
+			assert.Equal(t, tt.expectedSteps, steps)
+		})
+	}
+}
+
+func TestFormatForceDeletePlan(t *testing.T) {
+	plan := (&forceDeleteOptions{}).planInstallationTrees(nil, []*inspect.InstallationTree{newForceDeleteTestTree()})
+	formatted := formatForceDeletePlan(plan)
+	assert.Contains(t, formatted, "The following steps will be executed in this order:\n"+
+		"   1. delete installation test/root\n"+
+		"   2. delete installation test/sub\n")
+	assert.Contains(t, formatted, "  12. remove finalizers from installation test/root\n")
+}
+
+func TestFormatDeployedWarning(t *testing.T) {
+	tests := []struct {
+		name          string
+		purgeDeployed bool
+		expected      string
+	}{
+		{
+			name: "DeployItems whose resources remain are listed",
+			expected: "Warning: the resources deployed by the following 2 deployItems will not be uninstalled and remain orphaned in the target clusters:\n" +
+				"- deployItem test/sub-helm (type landscaper.gardener.cloud/helm, target test/cluster): 1 deployed resources\n" +
+				"    Service ingress/ingress\n" +
+				"- deployItem test/root-job (type landscaper.gardener.cloud/container, target none): deployed resources unknown\n",
+		},
+		{
+			name:          "Purged deployItems are omitted",
+			purgeDeployed: true,
+			expected: "Warning: the resources deployed by the following 1 deployItems will not be uninstalled and remain orphaned in the target clusters:\n" +
+				"- deployItem test/root-job (type landscaper.gardener.cloud/container, target none): deployed resources unknown\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &forceDeleteOptions{purgeDeployed: tt.purgeDeployed}
+			plan := o.planInstallationTrees(nil, []*inspect.InstallationTree{newForceDeleteTestTree()})
+			warning, err := formatDeployedWarning(plan)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, warning)
+		})
+	}
+
+	t.Run("No deployItems", func(t *testing.T) {
+		o := &forceDeleteOptions{}
+		plan := o.planInstallationTrees(nil, []*inspect.InstallationTree{{Installation: &lsv1alpha1.Installation{}}})
+		warning, err := formatDeployedWarning(plan)
+		assert.NoError(t, err)
+		assert.Equal(t, "No deployed resources will remain orphaned in the target clusters\n", warning)
+	})
+}
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input     string
+		confirmed bool
+	}{
+		{input: "\n", confirmed: false},
+		{input: "n\n", confirmed: false},
+		{input: "y\n", confirmed: true},
+		{input: " YES \n", confirmed: true},
+		// no newline before the end of the input
+		{input: "yes", confirmed: true},
+		// end of the input without answer, e.g. if the input is not a terminal
+		{input: "", confirmed: false},
+	}
+
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		cmd.SetIn(bytes.NewBufferString(tt.input))
+		out := &bytes.Buffer{}
+		cmd.SetOut(out)
+
+		assert.Equal(t, tt.confirmed, confirm(cmd, "Delete? "), "input %q", tt.input)
+		assert.Contains(t, out.String(), "Delete? ")
+	}
+}
+
+func TestExecutePlansCollectsMessages(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	existing := &lsv1alpha1.Installation{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "test", Name: "root-succeeded"}, existing))
+	missing := &lsv1alpha1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "missing", Namespace: "test"}}
+
+	o := &forceDeleteOptions{k8sClient: fakeClient, workers: 2}
+	results := o.executePlans(context.TODO(), [][]forceDeleteStep{
+		o.planInstallationTrees(nil, []*inspect.InstallationTree{{Installation: existing}}),
+		o.planInstallationTrees(nil, []*inspect.InstallationTree{{Installation: missing}}),
+	})
+
+	assert.Len(t, results, 2)
+	assert.NoError(t, results[0].err)
+	assert.Equal(t, []string{"- deleted installation root-succeeded"}, results[0].messages)
+	assert.NoError(t, results[1].err)
+	assert.Equal(t, []string{"- already gone: installation missing", "- deleted installation missing"}, results[1].messages)
+}
@@ -0,0 +1,175 @@
+package installations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+type valuesOptions struct {
+	kubeconfig       string
+	installationName string
+	namespace        string
+	omode            string
+	showSecrets      bool
+
+	// exports selects spec.exports instead of spec.imports
+	exports bool
+}
+
+func NewImportsCommand(ctx context.Context) *cobra.Command {
+	return newValuesCommand(ctx, false)
+}
+
+func NewExportsCommand(ctx context.Context) *cobra.Command {
+	return newValuesCommand(ctx, true)
+}
+
+func newValuesCommand(ctx context.Context, exports bool) *cobra.Command {
+	opts := &valuesOptions{exports: exports}
+	name := "imports"
+	short := "Displays the imports of an installation, i.e. the DataObjects, Targets, Secrets and ConfigMaps referenced " +
+		"in spec.imports together with their decoded values."
+	if exports {
+		name = "exports"
+		short = "Displays the exports of an installation, i.e. the DataObjects and Targets referenced in spec.exports " +
+			"together with their decoded values."
+	}
+
+	cmd := &cobra.Command{
+		Use:     fmt.Sprintf("%s [installation-name] [--namespace namespace] [--kubeconfig kubeconfig.yaml]", name),
+		Args:    cobra.ExactArgs(1),
+		Example: fmt.Sprintf("landscaper-cli installations %s MY_INSTALLATION --namespace MY_NAMESPACE -o yaml", name),
+		Short:   short + " The content of secrets and target configurations is redacted unless --show-secrets is set.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd, logger.Log); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *valuesOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	installation := &lsv1alpha1.Installation{}
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: o.installationName, Namespace: o.namespace}, installation); err != nil {
+		return fmt.Errorf("cannot get installation %s: %w", o.installationName, err)
+	}
+
+	resolver := inspect.ValueResolver{
+		K8sClient:   k8sClient,
+		ShowSecrets: o.showSecrets,
+	}
+
+	var values []*inspect.ResolvedValue
+	if o.exports {
+		values = resolver.ResolveExports(ctx, installation)
+	} else {
+		values = resolver.ResolveImports(ctx, installation)
+	}
+
+	switch o.omode {
+	case OUTPUT_YAML:
+		marshaledValues, err := yaml.Marshal(values)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to yaml: %w", err)
+		}
+		cmd.Print(string(marshaledValues))
+	case OUTPUT_JSON:
+		marshaledValues, err := json.Marshal(values)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to json: %w", err)
+		}
+		cmd.Print(string(marshaledValues))
+	default:
+		output, err := formatValuesTable(values)
+		if err != nil {
+			return err
+		}
+		cmd.Print(output)
+	}
+
+	return nil
+}
+
+// formatValuesTable prints one line per import or export. The values are printed as single line json.
+func formatValuesTable(values []*inspect.ResolvedValue) (string, error) {
+	out := strings.Builder{}
+	w := tabwriter.NewWriter(&out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tSOURCE\tVALUE")
+
+	for _, value := range values {
+		content := ""
+		if value.Error != "" {
+			content = "error: " + value.Error
+		} else {
+			marshaledValue, err := json.Marshal(value.Value)
+			if err != nil {
+				return "", fmt.Errorf("failed marshaling value of %s: %w", value.Name, err)
+			}
+			content = string(marshaledValue)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", value.Name, value.Type, strings.Join(value.Sources, ", "), content)
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func (o *valuesOptions) validateArgs(args []string) error {
+	o.installationName = args[0]
+
+	switch o.omode {
+	case inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid option for '--output'/'-o' flag: %q", o.omode)
+	}
+}
+
+func (o *valuesOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+	fs.StringVarP(&o.omode, "output", "o", inspect.OutputTable, fmt.Sprintf("how the output is formatted. Valid values are %s, %s, and %s.", inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON))
+	fs.BoolVar(&o.showSecrets, "show-secrets", false, "show the content of secrets and target configurations instead of redacting it.")
+}
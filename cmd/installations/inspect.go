@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
 	"github.com/gardener/landscapercli/pkg/logger"
@@ -22,14 +25,29 @@ type statusOptions struct {
 	installationName string
 	namespace        string
 	omode            string
+	fromFile         string
+	fromDir          string
 
 	allNamespaces  bool
 	detailMode     bool
 	showOnlyFailed bool
 
+	phases   []string
+	selector string
+	outdated bool
+	stuckFor time.Duration
+
+	durations     bool
+	showResources bool
+	interactive   bool
+	dataFlow      bool
+
 	oyaml bool
 	ojson bool
 	owide bool
+
+	summaryPrinter *inspect.SummaryPrinter
+	graphPrinter   *inspect.GraphPrinter
 }
 
 const (
@@ -67,13 +85,9 @@ func NewInspectCommand(ctx context.Context) *cobra.Command {
 }
 
 func (o *statusOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
-	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	k8sClient, err := o.buildClient()
 	if err != nil {
-		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
-	}
-
-	if namespace != "" && o.namespace == "" {
-		o.namespace = namespace
+		return err
 	}
 
 	if o.allNamespaces {
@@ -98,22 +112,63 @@ func (o *statusOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Lo
 		// We don't need to do anything here, but it shouldn't go into the default
 		// case, since that one is used to detect invalid arguments and throws an error.
 	default:
-		return fmt.Errorf("invalid option for '--output'/'-o' flag: %q", o.omode)
+		if inspect.IsGraphOutput(o.omode) {
+			o.graphPrinter, err = inspect.NewGraphPrinter(o.omode, o.allNamespaces)
+			if err != nil {
+				return fmt.Errorf("invalid option for '--output'/'-o' flag: %w", err)
+			}
+			break
+		}
+		if !inspect.IsSummaryOutput(o.omode) {
+			return fmt.Errorf("invalid option for '--output'/'-o' flag: %q", o.omode)
+		}
+		o.summaryPrinter, err = inspect.NewSummaryPrinter(o.omode)
+		if err != nil {
+			return fmt.Errorf("invalid option for '--output'/'-o' flag: %w", err)
+		}
 	}
 
 	// verify mode
 	if (o.oyaml || o.ojson || o.owide) && !xor(o.oyaml, o.ojson, o.owide) {
 		return fmt.Errorf("no more than one output mode may be set: yaml=%v, json=%v, wide=%v", o.oyaml, o.ojson, o.owide)
 	}
+	if (o.summaryPrinter != nil || o.graphPrinter != nil) && (o.oyaml || o.ojson || o.owide) {
+		return fmt.Errorf("no more than one output mode may be set: %s, yaml=%v, json=%v, wide=%v", o.omode, o.oyaml, o.ojson, o.owide)
+	}
+	if o.dataFlow && o.graphPrinter == nil {
+		return fmt.Errorf("the --data-flow option can only be used with the output formats %s, %s and %s", inspect.OutputDot, inspect.OutputMermaid, inspect.OutputHTML)
+	}
+
+	if o.interactive && (o.oyaml || o.ojson || o.owide || o.summaryPrinter != nil || o.graphPrinter != nil || o.detailMode) {
+		return fmt.Errorf("the --interactive option cannot be used together with an output format or --show-details")
+	}
+
+	filter, err := o.buildFilter()
+	if err != nil {
+		return err
+	}
 
 	collector := inspect.Collector{
 		K8sClient: k8sClient,
+		Progress:  cmd.ErrOrStderr(),
+	}
+	if o.interactive {
+		return o.runInteractive(ctx, cmd, k8sClient, &collector, filter)
 	}
-	installationTrees, err := collector.CollectInstallationsInCluster(o.installationName, o.namespace)
+
+	installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
 	if err != nil {
 		return fmt.Errorf("cannot collect installation: %w", err)
 	}
 
+	installationTrees = filter.FilterTrees(installationTrees)
+
+	if o.showResources {
+		if err := collector.CollectManagedResources(ctx, installationTrees, nil); err != nil {
+			return fmt.Errorf("cannot collect managed resources: %w", err)
+		}
+	}
+
 	if o.oyaml {
 		marshaledInstallationTrees, err := yaml.Marshal(installationTrees)
 		if err != nil {
@@ -132,7 +187,21 @@ func (o *statusOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Lo
 		return nil
 	}
 
-	transformer := inspect.NewTransformer(o.detailMode, o.showOnlyFailed, o.allNamespaces, o.owide)
+	transformer := inspect.NewTransformer(o.detailMode, o.showOnlyFailed, o.allNamespaces, o.owide).
+		WithDurations(o.durations).
+		WithDataFlow(o.dataFlow)
+
+	if o.summaryPrinter != nil {
+		return o.summaryPrinter.Print(cmd.OutOrStdout(), transformer.TransformToSummary(installationTrees))
+	}
+
+	if o.graphPrinter != nil {
+		graph, err := transformer.TransformToGraph(installationTrees)
+		if err != nil {
+			return fmt.Errorf("error transforming CR to graph: %w", err)
+		}
+		return o.graphPrinter.Print(cmd.OutOrStdout(), graph)
+	}
 
 	transformedTrees, err := transformer.TransformToPrintableTrees(installationTrees)
 	if err != nil {
@@ -145,6 +214,63 @@ func (o *statusOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Lo
 	return nil
 }
 
+// buildClient returns an in-memory client if the installations are inspected offline from a dump, otherwise a client
+// for the cluster.
+func (o *statusOptions) buildClient() (client.Client, error) {
+	if o.fromFile != "" || o.fromDir != "" {
+		if o.kubeconfig != "" {
+			return nil, fmt.Errorf("the --kubeconfig option cannot be used together with --from-file or --from-dir")
+		}
+		if o.fromFile != "" && o.fromDir != "" {
+			return nil, fmt.Errorf("the --from-file and --from-dir options cannot be used together")
+		}
+
+		// a dump has no current namespace, so all namespaces are inspected by default
+		if o.namespace == "" && len(o.installationName) == 0 {
+			o.allNamespaces = true
+		}
+
+		if o.fromFile != "" {
+			k8sClient, err := inspect.NewOfflineClientFromFile(scheme, o.fromFile)
+			if err != nil {
+				return nil, fmt.Errorf("cannot load objects from file: %w", err)
+			}
+			return k8sClient, nil
+		}
+		k8sClient, err := inspect.NewOfflineClientFromDir(scheme, o.fromDir)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load objects from directory: %w", err)
+		}
+		return k8sClient, nil
+	}
+
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+	return k8sClient, nil
+}
+
+func (o *statusOptions) buildFilter() (*inspect.TreeFilter, error) {
+	filter := &inspect.TreeFilter{
+		Phases:   o.phases,
+		Outdated: o.outdated,
+		StuckFor: o.stuckFor,
+	}
+	if o.selector != "" {
+		selector, err := labels.Parse(o.selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %w", o.selector, err)
+		}
+		filter.Selector = selector
+	}
+	return filter, nil
+}
+
 func (o *statusOptions) validateArgs(args []string) error {
 	if len(args) == 1 {
 		o.installationName = args[0]
@@ -158,10 +284,22 @@ func (o *statusOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "if present, lists installations across all namespaces. No installation name may be given and any given namespace will be ignored.")
 	fs.BoolVarP(&o.detailMode, "show-details", "d", false, "show detailed information about installations, executions and deployitems. Similar to kubectl describe installation installation-name.")
 	fs.BoolVarP(&o.showOnlyFailed, "show-failed", "f", false, "show only items that are in phase 'Failed'. It also prints parent elements to the failed items.")
+	fs.StringSliceVar(&o.phases, "phase", nil, "show only items in one of the given phases, e.g. 'Progressing,Failed'. It also prints parent elements to the matching items.")
+	fs.StringVarP(&o.selector, "selector", "l", "", "show only installations matching the label selector, together with their subinstallations, executions and deployitems. It also prints parent elements to the matching installations.")
+	fs.BoolVar(&o.outdated, "outdated", false, "show only items whose job ID differs from the job ID of their root installation. It also prints parent elements to the outdated items.")
+	fs.DurationVar(&o.stuckFor, "stuck-for", 0, "show only items that have been in a non-final phase for longer than the given duration, e.g. '30m'. It also prints parent elements to the stuck items.")
+	fs.BoolVar(&o.durations, "durations", false, "annotate each item with the duration of its last job, or how long the current job has been running.")
+	fs.BoolVar(&o.showResources, "show-resources", false, "show the kubernetes objects deployed by helm and manifest deployitems together with their readiness in the target cluster.")
+	fs.BoolVarP(&o.interactive, "interactive", "i", false, "show the installations in a full screen terminal UI, in which items can be expanded and collapsed, their yaml, events and logs can be shown, and root installations can be reconciled or interrupted. Press '?' for help.")
+	fs.BoolVar(&o.dataFlow, "data-flow", false, "draw edges between sibling installations which export and import the same DataObject or Target. Only valid with the output formats dot, mermaid and html.")
 	fs.BoolVarP(&o.oyaml, "oyaml", "y", false, "output in yaml format. Equivalent to '-o yaml'.")
 	fs.BoolVarP(&o.ojson, "ojson", "j", false, "output in json format. Equivalent to '-o json'.")
 	fs.BoolVarP(&o.owide, "owide", "w", false, "output some additional information. Equivalent to '-o wide'.")
-	fs.StringVarP(&o.omode, "output", "o", "", fmt.Sprintf("how the output is formatted. Valid values are %s, %s, and %s.", OUTPUT_YAML, OUTPUT_JSON, OUTPUT_WIDE))
+	fs.StringVar(&o.fromFile, "from-file", "", "inspect the installations of a yaml or json dump instead of a cluster, e.g. the output of 'kubectl get installations,executions,deployitems -o yaml'. Use '-' to read from stdin.")
+	fs.StringVar(&o.fromDir, "from-dir", "", "inspect the installations of all yaml and json files in a directory instead of a cluster, e.g. an unpacked support bundle.")
+	fs.StringVarP(&o.omode, "output", "o", "", fmt.Sprintf("how the output is formatted. Valid values are %s, %s, %s, %s, %s, %s, %s, %s, %s=<spec>, %s=<template>, and %s=<template>. "+
+		"The formats %s, %s and %s render the installations as graph, the last five formats are computed from a condensed summary of the installations, see docs/commands/installations/inspect.md.",
+		OUTPUT_YAML, OUTPUT_JSON, OUTPUT_WIDE, inspect.OutputDot, inspect.OutputMermaid, inspect.OutputHTML, inspect.OutputTable, inspect.OutputSummaryJSON, inspect.OutputCustomColumns, inspect.OutputJSONPath, inspect.OutputGoTemplate, inspect.OutputDot, inspect.OutputMermaid, inspect.OutputHTML))
 }
 
 // xor returns true if exactly one of the given booleans is true
@@ -3,45 +3,63 @@ package tree
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
 	"github.com/gardener/landscaper/pkg/landscaper/installations"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	// listPageSize is the maximal number of objects fetched with one list request.
+	listPageSize = 500
+	// progressDelay is the time after which the collector starts to report its progress.
+	progressDelay = 2 * time.Second
+)
+
 // Collector is responsible for collecting CR (installations, executions, deployItems) from a cluster using the K8sClient.
+// Each kind is listed once per namespace and the trees are assembled in memory.
 type Collector struct {
 	K8sClient client.Client
+	// Progress is optional. If set, the collector writes progress messages to it if collecting takes longer than a few
+	// seconds, e.g. in large namespaces.
+	Progress io.Writer
 }
 
 // CollectInstallationsInCluster collects a single installation (including all referenced executions and deployitems)
-// or all installations if name is empty.
-func (c *Collector) CollectInstallationsInCluster(name string, namespace string) ([]*InstallationTree, error) {
-	ctx := context.TODO()
+// or all installations if name is empty. A single installation is read with label selectors, so that only the objects
+// of its tree are fetched.
+func (c *Collector) CollectInstallationsInCluster(ctx context.Context, name string, namespace string) ([]*InstallationTree, error) {
+	objects := newObjectIndex(c, time.Now())
 
 	if name != "" {
-		installation, err := c.collectInstallationTree(ctx, name, namespace)
+		inst, err := objects.loadInstallation(ctx, namespace, name)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving installation %s: %w", name, err)
+		}
+		installation, err := objects.buildInstallationTree(ctx, inst)
 		if err != nil {
 			return nil, fmt.Errorf("error resolving installation %s: %w", name, err)
 		}
 		return []*InstallationTree{installation}, nil
 	}
 
-	instList := lsv1alpha1.InstallationList{}
-	installationTreeList := []*InstallationTree{}
-
+	listNamespace := namespace
 	if namespace == "*" {
-		if err := c.K8sClient.List(ctx, &instList); err != nil {
-			return nil, fmt.Errorf("cannot list installations across all namespaces: %w", err)
-		}
-	} else {
-		if err := c.K8sClient.List(ctx, &instList, client.InNamespace(namespace)); err != nil {
-			return nil, fmt.Errorf("cannot list installations for namespace %s: %w", namespace, err)
-		}
+		listNamespace = ""
 	}
-	for _, inst := range instList.Items {
-		if installations.IsRootInstallation(&inst) {
-			filledInst, err := c.collectInstallationTree(ctx, inst.Name, inst.Namespace)
+	if err := objects.load(ctx, listNamespace); err != nil {
+		return nil, err
+	}
+
+	installationTreeList := []*InstallationTree{}
+	for _, inst := range objects.installationList {
+		if installations.IsRootInstallation(inst) {
+			filledInst, err := objects.buildInstallationTree(ctx, inst)
 			if err != nil {
 				return nil, fmt.Errorf("cannot get installation details for %s: %w", inst.Name, err)
 			}
@@ -51,31 +69,245 @@ func (c *Collector) CollectInstallationsInCluster(name string, namespace string)
 	return installationTreeList, nil
 }
 
-func (c *Collector) collectInstallationTree(ctx context.Context, name string, namespace string) (*InstallationTree, error) {
-	key := client.ObjectKey{Name: name, Namespace: namespace}
-	inst := lsv1alpha1.Installation{}
-	err := c.K8sClient.Get(ctx, key, &inst)
+// CollectNamedInstallations collects the installation trees of the named installations of a namespace. The namespace
+// is listed once, so that also installations whose parent is gone are found. Installations that are part of the tree
+// of another named installation are not returned separately. The names of installations that do not exist are returned
+// as missing.
+func (c *Collector) CollectNamedInstallations(ctx context.Context, names []string, namespace string) ([]*InstallationTree, []string, error) {
+	objects := newObjectIndex(c, time.Now())
+	if err := objects.load(ctx, namespace); err != nil {
+		return nil, nil, err
+	}
+
+	named := map[string]bool{}
+	missing := []string{}
+	for _, name := range names {
+		if _, ok := objects.installations[objectKey(namespace, name)]; ok {
+			named[name] = true
+		} else {
+			missing = append(missing, name)
+		}
+	}
+
+	installationTrees := []*InstallationTree{}
+	collected := map[string]bool{}
+	for _, name := range names {
+		inst, ok := objects.installations[objectKey(namespace, name)]
+		if !ok || collected[name] || hasNamedAncestor(objects, inst, named) {
+			continue
+		}
+		collected[name] = true
+		installationTree, err := objects.buildInstallationTree(ctx, inst)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error resolving installation %s: %w", name, err)
+		}
+		installationTrees = append(installationTrees, installationTree)
+	}
+	return installationTrees, missing, nil
+}
+
+// hasNamedAncestor returns whether a parent installation, or one of its parents, is named.
+func hasNamedAncestor(objects *objectIndex, inst *lsv1alpha1.Installation, named map[string]bool) bool {
+	visited := map[string]bool{inst.Name: true}
+	for {
+		parent, ok := inst.Labels[lsv1alpha1.EncompassedByLabel]
+		if !ok || visited[parent] {
+			return false
+		}
+		if named[parent] {
+			return true
+		}
+		visited[parent] = true
+		if inst, ok = objects.installations[objectKey(inst.Namespace, parent)]; !ok {
+			return false
+		}
+	}
+}
+
+// objectIndex contains the installations, executions and deployItems of the loaded namespaces, indexed by
+// namespace/name and by the labels that reference their parent objects.
+type objectIndex struct {
+	collector *Collector
+	start     time.Time
+
+	// loadedNamespaces contains the namespaces that were listed. The empty string stands for all namespaces.
+	loadedNamespaces map[string]bool
+	// scoped is set if only the objects of a single installation tree are loaded. No namespace is listed then.
+	scoped bool
+
+	installationList     []*lsv1alpha1.Installation
+	installations        map[string]*lsv1alpha1.Installation
+	subInstallations     map[string][]*lsv1alpha1.Installation
+	executions           map[string]*lsv1alpha1.Execution
+	deployItemList       []*lsv1alpha1.DeployItem
+	executionDeployItems map[string][]*lsv1alpha1.DeployItem
+}
+
+func newObjectIndex(collector *Collector, start time.Time) *objectIndex {
+	return &objectIndex{
+		collector:            collector,
+		start:                start,
+		loadedNamespaces:     map[string]bool{},
+		installations:        map[string]*lsv1alpha1.Installation{},
+		subInstallations:     map[string][]*lsv1alpha1.Installation{},
+		executions:           map[string]*lsv1alpha1.Execution{},
+		executionDeployItems: map[string][]*lsv1alpha1.DeployItem{},
+	}
+}
+
+func objectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// load lists all installations, executions and deployItems of the namespace, unless it was loaded before.
+func (x *objectIndex) load(ctx context.Context, namespace string) error {
+	if x.loadedNamespaces[""] || x.loadedNamespaces[namespace] {
+		return nil
+	}
+	x.loadedNamespaces[namespace] = true
+
+	err := x.list(ctx, namespace, "installations", &lsv1alpha1.InstallationList{}, x.addInstallations)
 	if err != nil {
+		return err
+	}
+
+	err = x.list(ctx, namespace, "executions", &lsv1alpha1.ExecutionList{}, func(list client.ObjectList) {
+		for i := range list.(*lsv1alpha1.ExecutionList).Items {
+			exec := &list.(*lsv1alpha1.ExecutionList).Items[i]
+			x.executions[objectKey(exec.Namespace, exec.Name)] = exec
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	return x.list(ctx, namespace, "deployitems", &lsv1alpha1.DeployItemList{}, x.addDeployItems)
+}
+
+// loadInstallation gets an installation and loads its subinstallations, executions and deployItems.
+func (x *objectIndex) loadInstallation(ctx context.Context, namespace, name string) (*lsv1alpha1.Installation, error) {
+	x.scoped = true
+
+	inst := &lsv1alpha1.Installation{}
+	if err := x.collector.K8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, inst); err != nil {
 		return nil, fmt.Errorf("cannot get installation %s: %w", name, err)
 	}
+	x.installationList = append(x.installationList, inst)
+	x.installations[objectKey(inst.Namespace, inst.Name)] = inst
 
-	tree := InstallationTree{
-		Installation: &inst,
+	if err := x.loadInstallationChildren(ctx, inst); err != nil {
+		return nil, err
 	}
+	return inst, nil
+}
 
-	//resolve all sub installations
-	subInstList := &lsv1alpha1.InstallationList{}
-	err = c.K8sClient.List(ctx, subInstList,
-		client.InNamespace(inst.Namespace),
-		client.MatchingLabels{
-			lsv1alpha1.EncompassedByLabel: inst.Name,
-		})
+// loadInstallationChildren lists the subinstallations of an installation and the deployItems of its execution by the
+// labels that reference their parent objects, and continues with the subinstallations.
+func (x *objectIndex) loadInstallationChildren(ctx context.Context, inst *lsv1alpha1.Installation) error {
+	err := x.list(ctx, inst.Namespace, "installations", &lsv1alpha1.InstallationList{}, x.addInstallations,
+		client.MatchingLabels{lsv1alpha1.EncompassedByLabel: inst.Name})
 	if err != nil {
-		return nil, fmt.Errorf("cannot get subinstallations of %s: %w", inst.Name, err)
+		return err
 	}
+	for _, subInst := range x.subInstallations[objectKey(inst.Namespace, inst.Name)] {
+		if err := x.loadInstallationChildren(ctx, subInst); err != nil {
+			return err
+		}
+	}
+
+	execution := inst.Status.ExecutionReference
+	if execution == nil {
+		return nil
+	}
+	exec := &lsv1alpha1.Execution{}
+	if err := x.collector.K8sClient.Get(ctx, client.ObjectKey{Namespace: execution.Namespace, Name: execution.Name}, exec); err != nil {
+		if apierrors.IsNotFound(err) {
+			// reported when the execution tree is built
+			return nil
+		}
+		return fmt.Errorf("cannot get execution %s: %w", execution.Name, err)
+	}
+	x.executions[objectKey(exec.Namespace, exec.Name)] = exec
+
+	return x.list(ctx, exec.Namespace, "deployitems", &lsv1alpha1.DeployItemList{}, x.addDeployItems,
+		client.MatchingLabels{lsv1alpha1.ExecutionManagedByLabel: exec.Name})
+}
 
-	for _, subInst := range subInstList.Items {
-		subInstTree, err := c.collectInstallationTree(ctx, subInst.Name, namespace)
+func (x *objectIndex) addInstallations(list client.ObjectList) {
+	for i := range list.(*lsv1alpha1.InstallationList).Items {
+		inst := &list.(*lsv1alpha1.InstallationList).Items[i]
+		x.installationList = append(x.installationList, inst)
+		x.installations[objectKey(inst.Namespace, inst.Name)] = inst
+		if parent, ok := inst.Labels[lsv1alpha1.EncompassedByLabel]; ok {
+			key := objectKey(inst.Namespace, parent)
+			x.subInstallations[key] = append(x.subInstallations[key], inst)
+		}
+	}
+}
+
+func (x *objectIndex) addDeployItems(list client.ObjectList) {
+	for i := range list.(*lsv1alpha1.DeployItemList).Items {
+		depItem := &list.(*lsv1alpha1.DeployItemList).Items[i]
+		x.deployItemList = append(x.deployItemList, depItem)
+		if exec, ok := depItem.Labels[lsv1alpha1.ExecutionManagedByLabel]; ok {
+			key := objectKey(depItem.Namespace, exec)
+			x.executionDeployItems[key] = append(x.executionDeployItems[key], depItem)
+		}
+	}
+}
+
+// list lists all objects of a kind page by page. A new list object is used for every page, because the items of a
+// page are referenced by the index.
+func (x *objectIndex) list(ctx context.Context, namespace, resource string, list client.ObjectList, addPage func(list client.ObjectList), listOpts ...client.ListOption) error {
+	count := 0
+	continueToken := ""
+	for {
+		page := list.DeepCopyObject().(client.ObjectList)
+		opts := append([]client.ListOption{client.Limit(listPageSize), client.Continue(continueToken)}, listOpts...)
+		if namespace != "" {
+			opts = append(opts, client.InNamespace(namespace))
+		}
+
+		if err := x.collector.K8sClient.List(ctx, page, opts...); err != nil {
+			if namespace == "" {
+				return fmt.Errorf("cannot list %s across all namespaces: %w", resource, err)
+			}
+			return fmt.Errorf("cannot list %s for namespace %s: %w", resource, namespace, err)
+		}
+
+		addPage(page)
+		count += meta.LenList(page)
+
+		continueToken = page.GetContinue()
+		x.reportProgress(namespace, resource, count, continueToken == "")
+		if continueToken == "" {
+			return nil
+		}
+	}
+}
+
+func (x *objectIndex) reportProgress(namespace, resource string, count int, done bool) {
+	if x.collector.Progress == nil || time.Since(x.start) < progressDelay {
+		return
+	}
+	if namespace == "" {
+		namespace = "all namespaces"
+	}
+	status := "listing"
+	if done {
+		status = "listed"
+	}
+	fmt.Fprintf(x.collector.Progress, "%s %d %s in %s (%s)\n", status, count, resource, namespace, time.Since(x.start).Round(time.Second))
+}
+
+func (x *objectIndex) buildInstallationTree(ctx context.Context, inst *lsv1alpha1.Installation) (*InstallationTree, error) {
+	tree := InstallationTree{
+		Installation: inst,
+	}
+
+	//resolve all sub installations
+	for _, subInst := range x.subInstallations[objectKey(inst.Namespace, inst.Name)] {
+		subInstTree, err := x.buildInstallationTree(ctx, subInst)
 		if err != nil {
 			return nil, fmt.Errorf("cannot get installation %s: %w", subInst.Name, err)
 		}
@@ -85,7 +317,7 @@ func (c *Collector) collectInstallationTree(ctx context.Context, name string, na
 	//resolve executions
 	execution := inst.Status.ExecutionReference
 	if execution != nil {
-		subExecution, err := c.collectExecutionTree(ctx, execution.Name, execution.Namespace)
+		subExecution, err := x.buildExecutionTree(ctx, execution.Name, execution.Namespace)
 		if err != nil {
 			return nil, fmt.Errorf("cannot get execution %s: %w", execution.Name, err)
 		}
@@ -95,48 +327,29 @@ func (c *Collector) collectInstallationTree(ctx context.Context, name string, na
 	return &tree, nil
 }
 
-func (c *Collector) collectExecutionTree(ctx context.Context, name string, namespace string) (*ExecutionTree, error) {
-	key := client.ObjectKey{Name: name, Namespace: namespace}
-	exec := lsv1alpha1.Execution{}
-	err := c.K8sClient.Get(ctx, key, &exec)
-	if err != nil {
+func (x *objectIndex) buildExecutionTree(ctx context.Context, name string, namespace string) (*ExecutionTree, error) {
+	// executions are usually in the namespace of their installation, which has been loaded already
+	if !x.scoped {
+		if err := x.load(ctx, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	exec, ok := x.executions[objectKey(namespace, name)]
+	if !ok {
+		err := apierrors.NewNotFound(schema.GroupResource{Group: lsv1alpha1.SchemeGroupVersion.Group, Resource: "executions"}, name)
 		return nil, fmt.Errorf("cannot get execution %s: %w", name, err)
 	}
 
 	tree := ExecutionTree{
-		Execution: &exec,
+		Execution: exec,
 	}
 
 	//resolve deployItems
-	deployItemList := &lsv1alpha1.DeployItemList{}
-	err = c.K8sClient.List(ctx, deployItemList,
-		client.MatchingLabels{lsv1alpha1.ExecutionManagedByLabel: exec.Name},
-		client.InNamespace(exec.Namespace))
-	if err != nil {
-		return nil, fmt.Errorf("cannot list deployitems of execution %s: %w", exec.Name, err)
-	}
-
-	for _, deployItem := range deployItemList.Items {
-		deployItemTree, err := c.collectDeployItemTree(ctx, deployItem.Name, deployItem.Namespace)
-		if err != nil {
-			return nil, fmt.Errorf("cannot get deployitem %s: %w", deployItem.Name, err)
-		}
-		tree.DeployItems = append(tree.DeployItems, deployItemTree)
-	}
-
-	return &tree, nil
-}
-
-func (c *Collector) collectDeployItemTree(ctx context.Context, name string, namespace string) (*DeployItemLeaf, error) {
-	key := client.ObjectKey{Name: name, Namespace: namespace}
-	depItem := lsv1alpha1.DeployItem{}
-	err := c.K8sClient.Get(ctx, key, &depItem)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get deployitem %s: %w", name, err)
-	}
-
-	tree := DeployItemLeaf{
-		DeployItem: &depItem,
+	for _, deployItem := range x.executionDeployItems[objectKey(exec.Namespace, exec.Name)] {
+		tree.DeployItems = append(tree.DeployItems, &DeployItemLeaf{
+			DeployItem: deployItem,
+		})
 	}
 
 	return &tree, nil
@@ -1,11 +1,15 @@
 package tree
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	"github.com/gardener/landscaper/test/utils/envtest"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func TestCollector(t *testing.T) {
@@ -30,7 +34,7 @@ func TestCollector(t *testing.T) {
 	expectedDeployitemIngress := state.DeployItems["inttest/ingress-hhsjf-deploy-kptjl"]
 	expectedDeployitemIngress.TypeMeta = metav1.TypeMeta{}
 
-	actualStructure, err := collector.CollectInstallationsInCluster("", "inttest")
+	actualStructure, err := collector.CollectInstallationsInCluster(context.TODO(), "", "inttest")
 	assert.NoError(t, err)
 
 	expectedStructure := []*InstallationTree{
@@ -67,7 +71,7 @@ func TestCollector(t *testing.T) {
 		assert.Equal(t, expectedStructure, actualStructure)
 	})
 
-	actualStructure, err = collector.CollectInstallationsInCluster("", "*")
+	actualStructure, err = collector.CollectInstallationsInCluster(context.TODO(), "", "*")
 	assert.NoError(t, err)
 	expectedFakeInstallation := state.Installations["default/fakeinst"]
 	expectedFakeInstallation.TypeMeta = metav1.TypeMeta{}
@@ -78,4 +82,95 @@ func TestCollector(t *testing.T) {
 			assert.Contains(t, actualStructure, it)
 		}
 	})
+
+	t.Run("Collection of a single installation", func(t *testing.T) {
+		actualStructure, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStructure, actualStructure)
+
+		_, err = collector.CollectInstallationsInCluster(context.TODO(), "unknown", "inttest")
+		assert.ErrorContains(t, err, "cannot get installation unknown")
+	})
+
+	t.Run("Each kind is listed once, a single installation with label selectors, and the collection is cancelled with the context", func(t *testing.T) {
+		listCalls := map[string]int{}
+		unscopedListCalls := 0
+		countingClient := interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listCalls[fmt.Sprintf("%T", list)]++
+				listOpts := &client.ListOptions{}
+				listOpts.ApplyOptions(opts)
+				if listOpts.LabelSelector == nil {
+					unscopedListCalls++
+				}
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return c.List(ctx, list, opts...)
+			},
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		})
+		countingCollector := Collector{
+			K8sClient: countingClient,
+		}
+
+		_, err := countingCollector.CollectInstallationsInCluster(context.TODO(), "", "inttest")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]int{
+			"*v1alpha1.InstallationList": 1,
+			"*v1alpha1.ExecutionList":    1,
+			"*v1alpha1.DeployItemList":   1,
+		}, listCalls)
+
+		listCalls = map[string]int{}
+		unscopedListCalls = 0
+		_, err = countingCollector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+		assert.NoError(t, err)
+		// the subinstallations of the 3 installations and the deployItems of the 2 executions
+		assert.Equal(t, map[string]int{
+			"*v1alpha1.InstallationList": 3,
+			"*v1alpha1.DeployItemList":   2,
+		}, listCalls)
+		assert.Equal(t, 0, unscopedListCalls)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = countingCollector.CollectInstallationsInCluster(ctx, "my-aggregation", "inttest")
+		assert.ErrorIs(t, err, context.Canceled)
+		_, err = countingCollector.CollectInstallationsInCluster(ctx, "", "inttest")
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestCollectNamedInstallations(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+
+	t.Run("Subinstallations of named installations are collected as part of them", func(t *testing.T) {
+		trees, missing, err := collector.CollectNamedInstallations(context.TODO(), []string{"server-gw64l", "my-aggregation", "unknown", "my-aggregation"}, "inttest")
+		assert.NoError(t, err)
+		assert.Len(t, trees, 1)
+		assert.Equal(t, "my-aggregation", trees[0].Installation.Name)
+		assert.Len(t, trees[0].SubInstallations, 2)
+		assert.Equal(t, []string{"unknown"}, missing)
+	})
+
+	t.Run("Subinstallations are collected on their own", func(t *testing.T) {
+		trees, missing, err := collector.CollectNamedInstallations(context.TODO(), []string{"ingress-hhsjf", "server-gw64l"}, "inttest")
+		assert.NoError(t, err)
+		assert.Len(t, trees, 2)
+		assert.Equal(t, "ingress-hhsjf", trees[0].Installation.Name)
+		assert.Len(t, trees[0].Execution.DeployItems, 1)
+		assert.Equal(t, "server-gw64l", trees[1].Installation.Name)
+		assert.Empty(t, missing)
+	})
 }
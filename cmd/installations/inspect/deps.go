@@ -0,0 +1,343 @@
+package tree
+
+import (
+	"fmt"
+	"sort"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+)
+
+// Sources of an import in the dependency graph.
+const (
+	ImportSourceSibling     = "sibling"
+	ImportSourceParent      = "parent"
+	ImportSourceUnsatisfied = "unsatisfied"
+)
+
+// Dependencies describes the data flow between the subinstallations of an installation. A subinstallation depends on
+// the siblings exporting the DataObjects and Targets it imports.
+type Dependencies struct {
+	// Installation is the name of the parent of the subinstallations.
+	Installation     string                         `json:"installation"`
+	Subinstallations []*SubinstallationDependencies `json:"subinstallations"`
+	// Order contains the names of the subinstallations in topological order, grouped into steps. The subinstallations
+	// of a step only depend on subinstallations of earlier steps. Subinstallations in cycles are not contained.
+	Order [][]string `json:"order"`
+	// Cycles contains the cycles between the subinstallations, each as a path from a subinstallation back to itself.
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// SubinstallationDependencies are the imports of a subinstallation together with their producers.
+type SubinstallationDependencies struct {
+	Name    string              `json:"name"`
+	Phase   string              `json:"phase"`
+	Imports []*DependencyImport `json:"imports"`
+	// BlockedBy contains the names of the blocking producers, if the subinstallation itself has not yet succeeded for
+	// the current job of the parent installation.
+	BlockedBy []string `json:"blockedBy,omitempty"`
+}
+
+// DependencyImport is an import of a DataObject or Target by a subinstallation.
+type DependencyImport struct {
+	// Name is the name of the import.
+	Name string `json:"name"`
+	// Type is one of data, target, targetList or targetMap.
+	Type string `json:"type"`
+	// Ref is the name of the imported DataObject or Target in the context of the parent installation.
+	Ref string `json:"ref"`
+	// Source is sibling, parent or unsatisfied.
+	Source string `json:"source"`
+	// Producers are the siblings exporting the DataObject or Target.
+	Producers []*DependencyProducer `json:"producers,omitempty"`
+}
+
+// DependencyProducer is a sibling exporting an imported DataObject or Target.
+type DependencyProducer struct {
+	Name  string `json:"name"`
+	Phase string `json:"phase"`
+	// Blocking is set if the producer has not succeeded for the current job of the parent installation, so that the
+	// consumer has to wait for it.
+	Blocking bool `json:"blocking"`
+	// Reason explains why the producer is blocking.
+	Reason string `json:"reason,omitempty"`
+}
+
+// ComputeDependencies computes the data flow between the direct subinstallations of the installation tree. Imports
+// of secrets, config maps and target list or map references are not considered, because they are not exported by
+// siblings.
+func ComputeDependencies(installationTree *InstallationTree) *Dependencies {
+	parent := installationTree.Installation
+	deps := &Dependencies{
+		Installation:     parent.Name,
+		Subinstallations: []*SubinstallationDependencies{},
+		Order:            [][]string{},
+	}
+
+	parentImports := map[string]bool{}
+	for _, dataImport := range parent.Spec.Imports.Data {
+		parentImports[dataImport.Name] = true
+	}
+	for _, targetImport := range parent.Spec.Imports.Targets {
+		parentImports[targetImport.Name] = true
+	}
+
+	subInstallations := make([]*lsv1alpha1.Installation, 0, len(installationTree.SubInstallations))
+	for _, subInstallation := range installationTree.SubInstallations {
+		subInstallations = append(subInstallations, subInstallation.Installation)
+	}
+	sort.Slice(subInstallations, func(i, j int) bool {
+		return subInstallations[i].Name < subInstallations[j].Name
+	})
+
+	producers := map[string][]*lsv1alpha1.Installation{}
+	for _, inst := range subInstallations {
+		for _, name := range exportedNames(inst) {
+			producers[name] = append(producers[name], inst)
+		}
+	}
+
+	// edges from each subinstallation to the siblings it depends on
+	dependsOn := map[string][]string{}
+	for _, inst := range subInstallations {
+		subDeps := &SubinstallationDependencies{
+			Name:    inst.Name,
+			Phase:   string(inst.Status.InstallationPhase),
+			Imports: []*DependencyImport{},
+		}
+
+		pending, _ := isBlockingProducer(parent, inst)
+		for _, imp := range dependencyImports(inst) {
+			for _, producer := range producers[imp.Ref] {
+				if producer.Name == inst.Name {
+					continue
+				}
+				blocking, reason := isBlockingProducer(parent, producer)
+				imp.Producers = append(imp.Producers, &DependencyProducer{
+					Name:     producer.Name,
+					Phase:    string(producer.Status.InstallationPhase),
+					Blocking: blocking,
+					Reason:   reason,
+				})
+				dependsOn[inst.Name] = appendUnique(dependsOn[inst.Name], producer.Name)
+				if blocking && pending {
+					subDeps.BlockedBy = appendUnique(subDeps.BlockedBy, producer.Name)
+				}
+			}
+
+			switch {
+			case len(imp.Producers) != 0:
+				imp.Source = ImportSourceSibling
+			case parentImports[imp.Ref]:
+				imp.Source = ImportSourceParent
+			default:
+				imp.Source = ImportSourceUnsatisfied
+			}
+			subDeps.Imports = append(subDeps.Imports, imp)
+		}
+
+		deps.Subinstallations = append(deps.Subinstallations, subDeps)
+	}
+
+	names := make([]string, 0, len(subInstallations))
+	for _, inst := range subInstallations {
+		names = append(names, inst.Name)
+	}
+	deps.Order = topologicalOrder(names, dependsOn)
+	deps.Cycles = findCycles(names, dependsOn)
+	return deps
+}
+
+// Unsatisfied returns the imports which are neither exported by a sibling nor imported by the parent installation,
+// prefixed with the name of the importing subinstallation.
+func (d *Dependencies) Unsatisfied() []string {
+	unsatisfied := []string{}
+	for _, subDeps := range d.Subinstallations {
+		for _, imp := range subDeps.Imports {
+			if imp.Source == ImportSourceUnsatisfied {
+				unsatisfied = append(unsatisfied, fmt.Sprintf("%s/%s", subDeps.Name, imp.Name))
+			}
+		}
+	}
+	return unsatisfied
+}
+
+// dependencyImports returns the imports of DataObjects and Targets of the installation, one per imported name.
+func dependencyImports(inst *lsv1alpha1.Installation) []*DependencyImport {
+	imports := []*DependencyImport{}
+	for _, dataImport := range inst.Spec.Imports.Data {
+		if len(dataImport.DataRef) != 0 {
+			imports = append(imports, &DependencyImport{Name: dataImport.Name, Type: ValueTypeData, Ref: dataImport.DataRef})
+		}
+	}
+	for _, targetImport := range inst.Spec.Imports.Targets {
+		if len(targetImport.Target) != 0 {
+			imports = append(imports, &DependencyImport{Name: targetImport.Name, Type: ValueTypeTarget, Ref: targetImport.Target})
+		}
+		for i, target := range targetImport.Targets {
+			imports = append(imports, &DependencyImport{Name: fmt.Sprintf("%s[%d]", targetImport.Name, i), Type: ValueTypeTargetList, Ref: target})
+		}
+		keys := []string{}
+		for key := range targetImport.TargetMap {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			imports = append(imports, &DependencyImport{Name: fmt.Sprintf("%s[%s]", targetImport.Name, key), Type: ValueTypeTargetMap, Ref: targetImport.TargetMap[key]})
+		}
+	}
+	return imports
+}
+
+// isBlockingProducer returns whether a consumer has to wait for the producer, i.e. the producer has not succeeded or
+// has not yet finished the current job of the parent installation. It also tells whether a consumer is still pending.
+func isBlockingProducer(parent, producer *lsv1alpha1.Installation) (bool, string) {
+	if producer.Status.InstallationPhase != lsv1alpha1.InstallationPhases.Succeeded {
+		phase := string(producer.Status.InstallationPhase)
+		if len(phase) == 0 {
+			phase = "no phase"
+		}
+		return true, fmt.Sprintf("in phase %s", phase)
+	}
+	if len(parent.Status.JobID) != 0 && producer.Status.JobIDFinished != parent.Status.JobID {
+		return true, fmt.Sprintf("has not finished job %s", parent.Status.JobID)
+	}
+	return false, ""
+}
+
+// topologicalOrder groups the names into steps, so that each name only depends on names of earlier steps. Names in
+// cycles and names depending on them are omitted.
+func topologicalOrder(names []string, dependsOn map[string][]string) [][]string {
+	done := map[string]bool{}
+	order := [][]string{}
+	for {
+		step := []string{}
+		for _, name := range names {
+			if done[name] {
+				continue
+			}
+			ready := true
+			for _, dependency := range dependsOn[name] {
+				if !done[dependency] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				step = append(step, name)
+			}
+		}
+		if len(step) == 0 {
+			return order
+		}
+		for _, name := range step {
+			done[name] = true
+		}
+		order = append(order, step)
+	}
+}
+
+// findCycles returns one cycle per strongly connected component with more than one name, as path from the
+// alphabetically first name of the component back to itself.
+func findCycles(names []string, dependsOn map[string][]string) [][]string {
+	// Tarjan's algorithm for strongly connected components
+	index := map[string]int{}
+	lowLink := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	components := [][]string{}
+
+	var connect func(name string)
+	connect = func(name string) {
+		index[name] = len(index)
+		lowLink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+
+		for _, dependency := range dependsOn[name] {
+			if _, visited := index[dependency]; !visited {
+				connect(dependency)
+				lowLink[name] = min(lowLink[name], lowLink[dependency])
+			} else if onStack[dependency] {
+				lowLink[name] = min(lowLink[name], index[dependency])
+			}
+		}
+
+		if lowLink[name] == index[name] {
+			component := []string{}
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == name {
+					break
+				}
+			}
+			if len(component) > 1 {
+				components = append(components, component)
+			}
+		}
+	}
+	for _, name := range names {
+		if _, visited := index[name]; !visited {
+			connect(name)
+		}
+	}
+
+	cycles := [][]string{}
+	for _, component := range components {
+		sort.Strings(component)
+		cycles = append(cycles, cyclePath(component, dependsOn))
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+// cyclePath returns the shortest path from the first name of the component back to itself.
+func cyclePath(component []string, dependsOn map[string][]string) []string {
+	inComponent := map[string]bool{}
+	for _, name := range component {
+		inComponent[name] = true
+	}
+
+	start := component[0]
+	previous := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		for _, dependency := range dependsOn[name] {
+			if !inComponent[dependency] {
+				continue
+			}
+			if dependency == start {
+				path := []string{start}
+				for n := name; n != start; n = previous[n] {
+					path = append(path, n)
+				}
+				path = append(path, start)
+				// the path has been collected backwards
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			if _, seen := previous[dependency]; !seen {
+				previous[dependency] = name
+				queue = append(queue, dependency)
+			}
+		}
+	}
+	return append(component, start)
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
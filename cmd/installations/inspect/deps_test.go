@@ -0,0 +1,85 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeDependencies(t *testing.T) {
+	t.Run("Dependencies of testdata", func(t *testing.T) {
+		fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+		assert.NoError(t, err)
+
+		collector := Collector{K8sClient: fakeClient}
+		installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+		assert.NoError(t, err)
+
+		deps := ComputeDependencies(installationTrees[0])
+		assert.Equal(t, "my-aggregation", deps.Installation)
+		assert.Equal(t, [][]string{{"ingress-hhsjf"}, {"server-gw64l"}}, deps.Order)
+		assert.Empty(t, deps.Cycles)
+		assert.Empty(t, deps.Unsatisfied())
+
+		// the server installation imports the namespace and cluster from the parent and the ingress class from its sibling
+		server := deps.Subinstallations[1]
+		assert.Equal(t, "server-gw64l", server.Name)
+		assert.Len(t, server.Imports, 3)
+		assert.Equal(t, ImportSourceParent, server.Imports[0].Source)
+		assert.Equal(t, &DependencyImport{
+			Name:      "ingressClass",
+			Type:      ValueTypeData,
+			Ref:       "myIngressClass",
+			Source:    ImportSourceSibling,
+			Producers: []*DependencyProducer{{Name: "ingress-hhsjf", Phase: "Succeeded"}},
+		}, server.Imports[1])
+		assert.Equal(t, ImportSourceParent, server.Imports[2].Source)
+		assert.Empty(t, server.BlockedBy)
+	})
+
+	t.Run("Cycles, blocking producers and unsatisfied imports", func(t *testing.T) {
+		installation := func(name string, phase lsv1alpha1.InstallationPhase, imports, exports []string) *InstallationTree {
+			inst := &lsv1alpha1.Installation{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			inst.Status.InstallationPhase = phase
+			inst.Status.JobIDFinished = "job-2"
+			for _, ref := range imports {
+				inst.Spec.Imports.Data = append(inst.Spec.Imports.Data, lsv1alpha1.DataImport{Name: ref + "-import", DataRef: ref})
+			}
+			for _, ref := range exports {
+				inst.Spec.Exports.Data = append(inst.Spec.Exports.Data, lsv1alpha1.DataExport{Name: ref + "-export", DataRef: ref})
+			}
+			return &InstallationTree{Installation: inst}
+		}
+
+		root := installation("root", lsv1alpha1.InstallationPhases.Progressing, nil, nil)
+		root.Installation.Status.JobID = "job-2"
+		root.SubInstallations = []*InstallationTree{
+			installation("a", lsv1alpha1.InstallationPhases.Succeeded, []string{"from-c"}, []string{"from-a"}),
+			installation("b", lsv1alpha1.InstallationPhases.Progressing, []string{"from-a"}, []string{"from-b"}),
+			installation("c", lsv1alpha1.InstallationPhases.Progressing, []string{"from-b"}, []string{"from-c"}),
+			installation("d", lsv1alpha1.InstallationPhases.Succeeded, []string{"missing"}, []string{"from-d"}),
+			installation("e", lsv1alpha1.InstallationPhases.Progressing, []string{"from-d"}, nil),
+			installation("f", lsv1alpha1.InstallationPhases.Progressing, []string{"from-e", "from-a"}, nil),
+		}
+		// d has succeeded for a previous job only
+		root.SubInstallations[3].Installation.Status.JobIDFinished = "job-1"
+
+		deps := ComputeDependencies(root)
+		assert.Equal(t, [][]string{{"d"}, {"e"}}, deps.Order)
+		assert.Equal(t, [][]string{{"a", "c", "b", "a"}}, deps.Cycles)
+		assert.Equal(t, []string{"d/missing-import", "f/from-e-import"}, deps.Unsatisfied())
+
+		assert.Equal(t, []string{"d"}, deps.Subinstallations[4].BlockedBy)
+		assert.Equal(t, "has not finished job job-2", deps.Subinstallations[4].Imports[0].Producers[0].Reason)
+		assert.Equal(t, []string{"b"}, deps.Subinstallations[2].BlockedBy)
+		assert.Equal(t, "in phase Progressing", deps.Subinstallations[2].Imports[0].Producers[0].Reason)
+		assert.Empty(t, deps.Subinstallations[1].BlockedBy)
+		// a has succeeded, so it does not wait for c
+		assert.True(t, deps.Subinstallations[0].Imports[0].Producers[0].Blocking)
+		assert.Empty(t, deps.Subinstallations[0].BlockedBy)
+	})
+}
@@ -0,0 +1,217 @@
+package tree
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FailureExplanation describes the deepest failed object of a failed branch of an installation tree.
+type FailureExplanation struct {
+	// Path contains the installations, the execution and the deployItem from the root installation down to the failed object.
+	Path []client.Object
+	// LastError is the last error of the failed object.
+	LastError *lsv1alpha1.Error
+	// FirstError is the first error since the job ID of a failed deployItem has changed.
+	FirstError *lsv1alpha1.Error
+	// ErrorHistory contains the last errors since the job ID of a failed deployItem has changed.
+	ErrorHistory []*lsv1alpha1.Error
+	// Events contains the Kubernetes events of all objects of the path.
+	Events []corev1.Event
+	// Suggestion is the suggested next action.
+	Suggestion string
+}
+
+// Cause returns the deepest failed object.
+func (f *FailureExplanation) Cause() client.Object {
+	return f.Path[len(f.Path)-1]
+}
+
+// ExplainFailures follows all failed branches of the installation tree down to the deepest failed objects, which are
+// in most cases deployItems. The events of the explanations are not filled, see Collector.CollectEvents.
+func ExplainFailures(installationTree *InstallationTree) []*FailureExplanation {
+	failedTree := installationTree.DeepCopy().filterForFailedInstallation()
+	if failedTree == nil {
+		return nil
+	}
+
+	explanations := []*FailureExplanation{}
+	failedTree.collectFailures(nil, &explanations)
+
+	unfinished := hasUnfinishedObjects(installationTree)
+	for _, explanation := range explanations {
+		explanation.Suggestion = suggestNextAction(installationTree.Installation, explanation, unfinished)
+	}
+	return explanations
+}
+
+func (i *InstallationTree) collectFailures(path []client.Object, explanations *[]*FailureExplanation) {
+	path = appendToPath(path, i.Installation)
+
+	if len(i.SubInstallations) == 0 && i.Execution == nil {
+		*explanations = append(*explanations, &FailureExplanation{
+			Path:      path,
+			LastError: i.Installation.Status.LastError,
+		})
+		return
+	}
+
+	for _, subInstallation := range i.SubInstallations {
+		subInstallation.collectFailures(path, explanations)
+	}
+	if i.Execution != nil {
+		i.Execution.collectFailures(path, explanations)
+	}
+}
+
+func (e *ExecutionTree) collectFailures(path []client.Object, explanations *[]*FailureExplanation) {
+	path = appendToPath(path, e.Execution)
+
+	if len(e.DeployItems) == 0 {
+		*explanations = append(*explanations, &FailureExplanation{
+			Path:      path,
+			LastError: e.Execution.Status.LastError,
+		})
+		return
+	}
+
+	for _, depItem := range e.DeployItems {
+		*explanations = append(*explanations, &FailureExplanation{
+			Path:         appendToPath(path, depItem.DeployItem),
+			LastError:    depItem.DeployItem.Status.LastError,
+			FirstError:   depItem.DeployItem.Status.FirstError,
+			ErrorHistory: depItem.DeployItem.Status.LastErrors,
+		})
+	}
+}
+
+// appendToPath appends the object to a copy of the path, so that sibling branches do not share their paths.
+func appendToPath(path []client.Object, obj client.Object) []client.Object {
+	newPath := make([]client.Object, 0, len(path)+1)
+	newPath = append(newPath, path...)
+	return append(newPath, obj)
+}
+
+func hasUnfinishedObjects(installationTree *InstallationTree) bool {
+	unfinished := false
+	installationTree.walk(func(_ client.Object, phase string) {
+		if !lsv1alpha1.InstallationPhase(phase).IsFinal() {
+			unfinished = true
+		}
+	})
+	return unfinished
+}
+
+func suggestNextAction(root *lsv1alpha1.Installation, explanation *FailureExplanation, unfinished bool) string {
+	reconcile := fmt.Sprintf("landscaper-cli installations reconcile %s --namespace %s", root.Name, root.Namespace)
+	interrupt := fmt.Sprintf("landscaper-cli installations interrupt %s --namespace %s", root.Name, root.Namespace)
+
+	if unfinished {
+		return fmt.Sprintf("Some objects of the installation tree are still being processed. If they are stuck, "+
+			"interrupt the processing with '%s', fix the error and start a new reconciliation with '%s'.", interrupt, reconcile)
+	}
+
+	lastError := explanation.LastError
+	if lastError == nil {
+		return fmt.Sprintf("The failed object reports no error. Check the events and the logs of the responsible "+
+			"deployer, then start a new reconciliation with '%s'.", reconcile)
+	}
+
+	codes := sets.New(lastError.Codes...)
+	cause := explanation.Cause()
+	switch {
+	case isImportError(cause, lastError):
+		return fmt.Sprintf("Fix the imports of installation %s, i.e. check that the referenced DataObjects, Targets, "+
+			"Secrets and ConfigMaps exist and are exported by a sibling or parent installation. "+
+			"Then start a new reconciliation with '%s'.", cause.GetName(), reconcile)
+	case codes.Has(lsv1alpha1.ErrorTimeout):
+		return fmt.Sprintf("The processing ran into a timeout. Check the target cluster and the responsible deployer, "+
+			"then start a new reconciliation with '%s'.", reconcile)
+	case codes.Has(lsv1alpha1.ErrorUnauthorized):
+		return fmt.Sprintf("The access was not authorized. Check the credentials of the target, "+
+			"then start a new reconciliation with '%s'.", reconcile)
+	case codes.Has(lsv1alpha1.ErrorConfigurationProblem):
+		return fmt.Sprintf("The configuration is invalid. Fix the installation, its imports or the blueprint, "+
+			"then start a new reconciliation with '%s'.", reconcile)
+	default:
+		return fmt.Sprintf("Fix the error, then start a new reconciliation with '%s'.", reconcile)
+	}
+}
+
+func isImportError(cause client.Object, lastError *lsv1alpha1.Error) bool {
+	if _, ok := cause.(*lsv1alpha1.Installation); !ok {
+		return false
+	}
+	text := strings.ToLower(lastError.Operation + " " + lastError.Reason + " " + lastError.Message)
+	return strings.Contains(text, "import")
+}
+
+// CollectEvents collects the Kubernetes events of all objects of the failure explanations, sorted by time.
+func (c *Collector) CollectEvents(ctx context.Context, explanations []*FailureExplanation) error {
+	eventsByNamespace := map[string][]corev1.Event{}
+
+	for _, explanation := range explanations {
+		explanation.Events = nil
+		for _, obj := range explanation.Path {
+			events, ok := eventsByNamespace[obj.GetNamespace()]
+			if !ok {
+				eventList := &corev1.EventList{}
+				if err := c.K8sClient.List(ctx, eventList, client.InNamespace(obj.GetNamespace())); err != nil {
+					return fmt.Errorf("cannot list events in namespace %s: %w", obj.GetNamespace(), err)
+				}
+				events = eventList.Items
+				eventsByNamespace[obj.GetNamespace()] = events
+			}
+
+			explanation.Events = append(explanation.Events, eventsOf(events, obj)...)
+		}
+
+		sortEvents(explanation.Events)
+	}
+
+	return nil
+}
+
+// CollectObjectEvents collects the Kubernetes events of an installation, execution or deployItem, sorted by time.
+func (c *Collector) CollectObjectEvents(ctx context.Context, obj client.Object) ([]corev1.Event, error) {
+	eventList := &corev1.EventList{}
+	if err := c.K8sClient.List(ctx, eventList, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil, fmt.Errorf("cannot list events in namespace %s: %w", obj.GetNamespace(), err)
+	}
+	events := eventsOf(eventList.Items, obj)
+	sortEvents(events)
+	return events, nil
+}
+
+func eventsOf(events []corev1.Event, obj client.Object) []corev1.Event {
+	result := []corev1.Event{}
+	for _, event := range events {
+		if event.InvolvedObject.Kind == KindOf(obj) && event.InvolvedObject.Name == obj.GetName() {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func sortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTime(events[i]).Before(eventTime(events[j]))
+	})
+}
+
+func eventTime(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if event.Series != nil {
+		return event.Series.LastObservedTime.Time
+	}
+	return event.EventTime.Time
+}
@@ -0,0 +1,65 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainFailures(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	installationTree := installationTrees[0]
+
+	t.Run("Succeeded installation", func(t *testing.T) {
+		assert.Empty(t, ExplainFailures(installationTree))
+	})
+
+	t.Run("Failed deployItem", func(t *testing.T) {
+		failedTree := installationTree.DeepCopy()
+		failedTree.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Failed
+		failedServer := failedTree.SubInstallations[1]
+		failedServer.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Failed
+		failedServer.Execution.Execution.Status.ExecutionPhase = lsv1alpha1.ExecutionPhases.Failed
+		failedDeployItem := failedServer.Execution.DeployItems[0].DeployItem
+		failedDeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Failed
+		failedDeployItem.Status.LastError = &lsv1alpha1.Error{
+			Message: "timeout",
+			Codes:   []lsv1alpha1.ErrorCode{lsv1alpha1.ErrorTimeout},
+		}
+
+		explanations := ExplainFailures(failedTree)
+		assert.Len(t, explanations, 1)
+		assert.Len(t, explanations[0].Path, 4)
+		assert.Equal(t, failedDeployItem, explanations[0].Cause())
+		assert.Equal(t, failedDeployItem.Status.LastError, explanations[0].LastError)
+		assert.Contains(t, explanations[0].Suggestion, "timeout")
+		assert.Contains(t, explanations[0].Suggestion, "landscaper-cli installations reconcile my-aggregation --namespace inttest")
+	})
+
+	t.Run("Failed installation with unsatisfied imports", func(t *testing.T) {
+		failedTree := installationTree.DeepCopy()
+		failedTree.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Failed
+		failedIngress := failedTree.SubInstallations[0]
+		failedIngress.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Failed
+		failedIngress.Installation.Status.LastError = &lsv1alpha1.Error{
+			Operation: "ImportsSatisfied",
+			Message:   "dataobject for import ingressClass not found",
+		}
+		failedIngress.Execution = nil
+
+		explanations := ExplainFailures(failedTree)
+		assert.Len(t, explanations, 1)
+		assert.Equal(t, failedIngress.Installation, explanations[0].Cause())
+		assert.Contains(t, explanations[0].Suggestion, "Fix the imports of installation ingress-hhsjf")
+	})
+}
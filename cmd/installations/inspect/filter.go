@@ -0,0 +1,129 @@
+package tree
+
+import (
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TreeFilter selects installations, executions and deployItems of installation trees. An object matches if it matches
+// all criteria that are set. As with filterForFailedInstallation, the parent elements of matching objects are kept.
+type TreeFilter struct {
+	// Phases contains the phases of matching objects. The comparison is case-insensitive.
+	Phases []string
+	// Selector selects installations by their labels. The executions, deployItems and subinstallations of a selected
+	// installation are selected as well.
+	Selector labels.Selector
+	// Outdated selects objects whose job ID differs from the job ID of their root installation.
+	Outdated bool
+	// StuckFor selects objects which have been in a non-final phase for longer than this duration.
+	StuckFor time.Duration
+}
+
+// IsEmpty returns whether no criteria are set, so that the filter matches every object.
+func (f *TreeFilter) IsEmpty() bool {
+	return len(f.Phases) == 0 && (f.Selector == nil || f.Selector.Empty()) && !f.Outdated && f.StuckFor == 0
+}
+
+// FilterTrees returns filtered deep copies of the installation trees. Trees without matching objects are omitted.
+func (f *TreeFilter) FilterTrees(installationTrees []*InstallationTree) []*InstallationTree {
+	if f.IsEmpty() {
+		return installationTrees
+	}
+
+	now := time.Now()
+	filteredTrees := []*InstallationTree{}
+	for _, installationTree := range installationTrees {
+		filteredTree := installationTree.DeepCopy()
+		if f.Selector != nil && !f.Selector.Empty() {
+			filteredTree = filteredTree.filterBySelector(f.Selector)
+			if filteredTree == nil {
+				continue
+			}
+		}
+
+		check := &outdatedCheck{installationTree.Installation.Status.JobID}
+		filteredTree = filteredTree.filter(func(obj client.Object, phase string) bool {
+			return f.matches(obj, phase, check, now)
+		})
+		if filteredTree != nil {
+			filteredTrees = append(filteredTrees, filteredTree)
+		}
+	}
+	return filteredTrees
+}
+
+func (f *TreeFilter) matches(obj client.Object, phase string, check *outdatedCheck, now time.Time) bool {
+	if len(f.Phases) > 0 && !containsPhase(f.Phases, phase) {
+		return false
+	}
+	if f.Outdated && !check.isOutdated(obj) {
+		return false
+	}
+	if f.StuckFor > 0 && !isStuck(obj, phase, f.StuckFor, now) {
+		return false
+	}
+	return true
+}
+
+// filterBySelector keeps the installations matching the selector with their complete subtrees, as well as their
+// parent installations.
+func (i *InstallationTree) filterBySelector(selector labels.Selector) *InstallationTree {
+	if selector.Matches(labels.Set(i.Installation.Labels)) {
+		return i
+	}
+
+	filteredSubInstallations := []*InstallationTree{}
+	for _, subInstallation := range i.SubInstallations {
+		if filteredSubInstallation := subInstallation.filterBySelector(selector); filteredSubInstallation != nil {
+			filteredSubInstallations = append(filteredSubInstallations, filteredSubInstallation)
+		}
+	}
+	if len(filteredSubInstallations) == 0 {
+		return nil
+	}
+
+	i.SubInstallations = filteredSubInstallations
+	i.Execution = nil
+	return i
+}
+
+// SelectInstallations returns the topmost installations of the trees for which matches returns true, with their
+// complete subtrees. Subinstallations of a selected installation are not returned separately, so that the returned
+// trees are disjoint.
+func SelectInstallations(installationTrees []*InstallationTree, matches func(inst *lsv1alpha1.Installation) bool) []*InstallationTree {
+	selected := []*InstallationTree{}
+	for _, installationTree := range installationTrees {
+		if matches(installationTree.Installation) {
+			selected = append(selected, installationTree)
+			continue
+		}
+		selected = append(selected, SelectInstallations(installationTree.SubInstallations, matches)...)
+	}
+	return selected
+}
+
+func containsPhase(phases []string, phase string) bool {
+	for _, p := range phases {
+		if strings.EqualFold(p, phase) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStuck returns whether the object has been in a non-final phase for longer than the threshold, based on the time
+// of its last phase transition. Objects without timestamps are not considered stuck.
+func isStuck(obj client.Object, phase string, threshold time.Duration, now time.Time) bool {
+	if lsv1alpha1.InstallationPhase(phase).IsFinal() {
+		return false
+	}
+	transitionTime := PhaseTransitionTimeOf(obj)
+	if transitionTime == nil {
+		return false
+	}
+	return now.Sub(transitionTime.Time) > threshold
+}
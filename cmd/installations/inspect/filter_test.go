@@ -0,0 +1,117 @@
+package tree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestTreeFilter(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	installationTree := installationTrees[0]
+
+	t.Run("Empty filter", func(t *testing.T) {
+		filter := &TreeFilter{}
+		assert.True(t, filter.IsEmpty())
+		assert.Equal(t, installationTrees, filter.FilterTrees(installationTrees))
+	})
+
+	t.Run("Filter by phase keeps parent elements", func(t *testing.T) {
+		tree := installationTree.DeepCopy()
+		tree.SubInstallations[1].Execution.DeployItems[0].DeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Progressing
+
+		filteredTrees := (&TreeFilter{Phases: []string{"progressing", "Failed"}}).FilterTrees([]*InstallationTree{tree})
+		assert.Len(t, filteredTrees, 1)
+		assert.Equal(t, "my-aggregation", filteredTrees[0].Installation.Name)
+		assert.Len(t, filteredTrees[0].SubInstallations, 1)
+		assert.Equal(t, "server-gw64l", filteredTrees[0].SubInstallations[0].Installation.Name)
+		assert.Len(t, filteredTrees[0].SubInstallations[0].Execution.DeployItems, 1)
+		// the original tree is not modified
+		assert.Len(t, tree.SubInstallations, 2)
+	})
+
+	t.Run("Filter by label selector", func(t *testing.T) {
+		tree := installationTree.DeepCopy()
+		tree.SubInstallations[0].Installation.Labels["app"] = "ingress"
+
+		filteredTrees := (&TreeFilter{Selector: labels.SelectorFromSet(labels.Set{"app": "ingress"})}).FilterTrees([]*InstallationTree{tree})
+		assert.Len(t, filteredTrees, 1)
+		assert.Nil(t, filteredTrees[0].Execution)
+		assert.Len(t, filteredTrees[0].SubInstallations, 1)
+		assert.Equal(t, "ingress-hhsjf", filteredTrees[0].SubInstallations[0].Installation.Name)
+		assert.Len(t, filteredTrees[0].SubInstallations[0].Execution.DeployItems, 1)
+
+		filteredTrees = (&TreeFilter{Selector: labels.SelectorFromSet(labels.Set{"app": "unknown"})}).FilterTrees([]*InstallationTree{tree})
+		assert.Empty(t, filteredTrees)
+	})
+
+	t.Run("Filter outdated objects", func(t *testing.T) {
+		tree := installationTree.DeepCopy()
+		tree.SubInstallations[0].Execution.DeployItems[0].DeployItem.Status.JobID = "old-job"
+
+		filteredTrees := (&TreeFilter{Outdated: true}).FilterTrees([]*InstallationTree{tree})
+		assert.Len(t, filteredTrees, 1)
+		assert.Len(t, filteredTrees[0].SubInstallations, 1)
+		assert.Equal(t, "old-job", filteredTrees[0].SubInstallations[0].Execution.DeployItems[0].DeployItem.Status.JobID)
+	})
+
+	t.Run("Filter stuck objects", func(t *testing.T) {
+		tree := installationTree.DeepCopy()
+		deployItem := tree.SubInstallations[1].Execution.DeployItems[0].DeployItem
+		deployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Progressing
+		initTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		deployItem.Status.TransitionTimes = &lsv1alpha1.TransitionTimes{InitTime: &initTime}
+
+		filteredTrees := (&TreeFilter{StuckFor: 30 * time.Minute}).FilterTrees([]*InstallationTree{tree})
+		assert.Len(t, filteredTrees, 1)
+		assert.Len(t, filteredTrees[0].SubInstallations, 1)
+		assert.Equal(t, deployItem.Name, filteredTrees[0].SubInstallations[0].Execution.DeployItems[0].DeployItem.Name)
+
+		filteredTrees = (&TreeFilter{StuckFor: 2 * time.Hour}).FilterTrees([]*InstallationTree{tree})
+		assert.Empty(t, filteredTrees)
+	})
+}
+
+func TestSelectInstallations(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "", "inttest")
+	assert.NoError(t, err)
+
+	t.Run("Topmost matching installations", func(t *testing.T) {
+		selected := SelectInstallations(installationTrees, func(inst *lsv1alpha1.Installation) bool {
+			return inst.Name == "my-aggregation" || inst.Name == "server-gw64l"
+		})
+		assert.Len(t, selected, 1)
+		assert.Equal(t, "my-aggregation", selected[0].Installation.Name)
+		assert.Len(t, selected[0].SubInstallations, 2)
+	})
+
+	t.Run("Subinstallations by label", func(t *testing.T) {
+		selector := labels.SelectorFromSet(labels.Set{lsv1alpha1.EncompassedByLabel: "my-aggregation"})
+		selected := SelectInstallations(installationTrees, func(inst *lsv1alpha1.Installation) bool {
+			return selector.Matches(labels.Set(inst.Labels))
+		})
+		assert.Len(t, selected, 2)
+		assert.Equal(t, "ingress-hhsjf", selected[0].Installation.Name)
+		assert.Equal(t, "server-gw64l", selected[1].Installation.Name)
+		assert.NotNil(t, selected[0].Execution)
+	})
+}
@@ -0,0 +1,304 @@
+package tree
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Graph is the representation of installation trees for the dot, mermaid and html outputs.
+type Graph struct {
+	// Roots are the nodes of the root installations. The nodes below them are reachable via their children.
+	Roots []*GraphNode
+	// Nodes contains all nodes in tree order.
+	Nodes []*GraphNode
+	Edges []*GraphEdge
+}
+
+// GraphNode is an installation, execution or deployItem of a Graph.
+type GraphNode struct {
+	// ID identifies the node within the graph, e.g. "n3".
+	ID        string
+	Kind      string
+	Namespace string
+	Name      string
+	Phase     string
+	Outdated  bool
+	LastError string
+	// Details contains the yaml of the object, in which the configurations of deployItems and executions and the import
+	// data mappings of installations are redacted, because they may contain secrets.
+	Details  string
+	Children []*GraphNode
+}
+
+// GraphEdge connects two nodes of a Graph. Edges from a parent to its children are always included, data flow edges
+// only if enabled with WithDataFlow.
+type GraphEdge struct {
+	From string
+	To   string
+	// DataFlow is true for edges from an installation exporting a DataObject or Target to a sibling installation
+	// importing it.
+	DataFlow bool
+	// Label contains the names of the exchanged DataObjects and Targets of a data flow edge.
+	Label string
+}
+
+// WithDataFlow lets the transformer add data flow edges between sibling installations to graphs.
+func (t *Transformer) WithDataFlow(dataFlow bool) *Transformer {
+	t.dataFlow = dataFlow
+	return t
+}
+
+// TransformToGraph transforms a []*InstallationTree to a Graph, applying the same filters as
+// TransformToPrintableTrees.
+func (t *Transformer) TransformToGraph(installationTrees []*InstallationTree) (*Graph, error) {
+	g := &Graph{
+		Roots: []*GraphNode{},
+		Nodes: []*GraphNode{},
+		Edges: []*GraphEdge{},
+	}
+
+	roots := []*InstallationTree{}
+	for _, installationTree := range installationTrees {
+		if t.showOnlyFailed {
+			installationTree = installationTree.filterForFailedInstallation()
+		}
+		if installationTree == nil {
+			continue
+		}
+		roots = append(roots, installationTree)
+	}
+
+	installationNodes := map[*InstallationTree]*GraphNode{}
+	for _, installationTree := range roots {
+		check := &outdatedCheck{installationTree.Installation.Status.JobID}
+		node, err := t.addInstallationNode(g, nil, installationTree, check, installationNodes)
+		if err != nil {
+			return nil, fmt.Errorf("error in installation %s: %w", installationTree.Installation.Name, err)
+		}
+		g.Roots = append(g.Roots, node)
+	}
+
+	if t.dataFlow {
+		// root installations exchange data via the DataObjects and Targets of their namespace
+		rootsByNamespace := map[string][]*InstallationTree{}
+		namespaces := []string{}
+		for _, installationTree := range roots {
+			namespace := installationTree.Installation.Namespace
+			if _, ok := rootsByNamespace[namespace]; !ok {
+				namespaces = append(namespaces, namespace)
+			}
+			rootsByNamespace[namespace] = append(rootsByNamespace[namespace], installationTree)
+		}
+		for _, namespace := range namespaces {
+			g.addDataFlowEdges(rootsByNamespace[namespace], installationNodes)
+		}
+		for _, installationTree := range roots {
+			g.addSubInstallationDataFlowEdges(installationTree, installationNodes)
+		}
+	}
+
+	return g, nil
+}
+
+func (t *Transformer) addInstallationNode(g *Graph, parent *GraphNode, installationTree *InstallationTree, check *outdatedCheck, installationNodes map[*InstallationTree]*GraphNode) (*GraphNode, error) {
+	inst := installationTree.Installation
+	node, err := g.addNode(parent, inst, string(inst.Status.InstallationPhase), check.isInstallationOutdated(inst), inst.Status.LastError)
+	if err != nil {
+		return nil, err
+	}
+	installationNodes[installationTree] = node
+
+	for _, subInstallation := range installationTree.SubInstallations {
+		if _, err := t.addInstallationNode(g, node, subInstallation, check, installationNodes); err != nil {
+			return nil, err
+		}
+	}
+
+	if installationTree.Execution != nil {
+		exec := installationTree.Execution.Execution
+		execNode, err := g.addNode(node, exec, string(exec.Status.ExecutionPhase), check.isExecutionOutdated(exec), exec.Status.LastError)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, deployItem := range installationTree.Execution.DeployItems {
+			di := deployItem.DeployItem
+			if _, err := g.addNode(execNode, di, string(di.Status.Phase), check.isDeployItemOutdated(di), di.Status.LastError); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return node, nil
+}
+
+// addNode adds a node for the object and an edge from the parent to it, unless the node is a root node.
+func (g *Graph) addNode(parent *GraphNode, obj client.Object, phase string, outdated bool, lastError *lsv1alpha1.Error) (*GraphNode, error) {
+	obj.SetManagedFields(nil)
+	redacted, err := redactObject(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting %s %s: %w", KindOf(obj), obj.GetName(), err)
+	}
+	details, err := yaml.Marshal(redacted)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling %s %s: %w", KindOf(obj), obj.GetName(), err)
+	}
+
+	node := &GraphNode{
+		ID:        fmt.Sprintf("n%d", len(g.Nodes)),
+		Kind:      KindOf(obj),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Phase:     phase,
+		Outdated:  outdated,
+		LastError: errorMessage(lastError),
+		Details:   string(details),
+	}
+	g.Nodes = append(g.Nodes, node)
+
+	if parent != nil {
+		parent.Children = append(parent.Children, node)
+		g.Edges = append(g.Edges, &GraphEdge{From: parent.ID, To: node.ID})
+	}
+	return node, nil
+}
+
+// redactObject returns the object as map, in which the values that may contain secrets are replaced by RedactedValue:
+// the import data mappings of installations, the deployItem templates of executions, the configuration of
+// deployItems, and the last applied configuration written by kubectl.
+func redactObject(obj client.Object) (map[string]interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	annotations, _, _ := unstructured.NestedStringMap(content, "metadata", "annotations")
+	if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		annotations[corev1.LastAppliedConfigAnnotation] = RedactedValue
+		if err := unstructured.SetNestedStringMap(content, annotations, "metadata", "annotations"); err != nil {
+			return nil, err
+		}
+	}
+
+	switch obj.(type) {
+	case *lsv1alpha1.Installation:
+		mappings, _, _ := unstructured.NestedMap(content, "spec", "importDataMappings")
+		for name := range mappings {
+			mappings[name] = RedactedValue
+		}
+		if len(mappings) > 0 {
+			if err := unstructured.SetNestedMap(content, mappings, "spec", "importDataMappings"); err != nil {
+				return nil, err
+			}
+		}
+	case *lsv1alpha1.Execution:
+		// executions are always marshaled with compressed deployItem templates
+		if _, ok, _ := unstructured.NestedFieldNoCopy(content, "spec", "deployItemsCompressed"); ok {
+			if err := unstructured.SetNestedField(content, RedactedValue, "spec", "deployItemsCompressed"); err != nil {
+				return nil, err
+			}
+		}
+	case *lsv1alpha1.DeployItem:
+		if _, ok, _ := unstructured.NestedFieldNoCopy(content, "spec", "config"); ok {
+			if err := unstructured.SetNestedField(content, RedactedValue, "spec", "config"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return content, nil
+}
+
+func (g *Graph) addSubInstallationDataFlowEdges(installationTree *InstallationTree, installationNodes map[*InstallationTree]*GraphNode) {
+	g.addDataFlowEdges(installationTree.SubInstallations, installationNodes)
+	for _, subInstallation := range installationTree.SubInstallations {
+		g.addSubInstallationDataFlowEdges(subInstallation, installationNodes)
+	}
+}
+
+// addDataFlowEdges adds an edge from each of the sibling installations exporting a DataObject or Target to each
+// sibling importing it. Siblings share the context of their parent, so exports and imports match by name.
+func (g *Graph) addDataFlowEdges(siblings []*InstallationTree, installationNodes map[*InstallationTree]*GraphNode) {
+	producers := map[string][]*GraphNode{}
+	for _, sibling := range siblings {
+		for _, name := range exportedNames(sibling.Installation) {
+			producers[name] = append(producers[name], installationNodes[sibling])
+		}
+	}
+
+	for _, sibling := range siblings {
+		consumer := installationNodes[sibling]
+		labels := map[string][]string{}
+		producerIDs := []string{}
+		for _, name := range importedNames(sibling.Installation) {
+			for _, producer := range producers[name] {
+				if producer == consumer {
+					continue
+				}
+				if _, ok := labels[producer.ID]; !ok {
+					producerIDs = append(producerIDs, producer.ID)
+				}
+				labels[producer.ID] = append(labels[producer.ID], name)
+			}
+		}
+		for _, producerID := range producerIDs {
+			g.Edges = append(g.Edges, &GraphEdge{
+				From:     producerID,
+				To:       consumer.ID,
+				DataFlow: true,
+				Label:    strings.Join(labels[producerID], ", "),
+			})
+		}
+	}
+}
+
+// exportedNames returns the names of the DataObjects and Targets exported by the installation.
+func exportedNames(inst *lsv1alpha1.Installation) []string {
+	names := []string{}
+	for _, dataExport := range inst.Spec.Exports.Data {
+		names = append(names, dataExport.DataRef)
+	}
+	for _, targetExport := range inst.Spec.Exports.Targets {
+		names = append(names, targetExport.Target)
+	}
+	return names
+}
+
+// importedNames returns the names of the DataObjects and Targets imported by the installation without duplicates.
+// Imports of secrets, config maps and target list or map references are not included.
+func importedNames(inst *lsv1alpha1.Installation) []string {
+	names := []string{}
+	seen := map[string]bool{}
+	add := func(name string) {
+		if len(name) != 0 && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for _, dataImport := range inst.Spec.Imports.Data {
+		add(dataImport.DataRef)
+	}
+	for _, targetImport := range inst.Spec.Imports.Targets {
+		add(targetImport.Target)
+		for _, target := range targetImport.Targets {
+			add(target)
+		}
+		keys := []string{}
+		for key := range targetImport.TargetMap {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			add(targetImport.TargetMap[key])
+		}
+	}
+	return names
+}
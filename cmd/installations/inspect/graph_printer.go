@@ -0,0 +1,300 @@
+package tree
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+)
+
+// Output formats which are computed from the Graph.
+const (
+	OutputDot     = "dot"
+	OutputMermaid = "mermaid"
+	OutputHTML    = "html"
+)
+
+// phaseStyle contains the colours of the nodes in a group of phases.
+type phaseStyle struct {
+	Class  string
+	Fill   string
+	Stroke string
+}
+
+var (
+	succeededStyle   = phaseStyle{Class: "succeeded", Fill: "#d4edda", Stroke: "#28a745"}
+	failedStyle      = phaseStyle{Class: "failed", Fill: "#f8d7da", Stroke: "#dc3545"}
+	progressingStyle = phaseStyle{Class: "progressing", Fill: "#fff3cd", Stroke: "#d39e00"}
+	unknownStyle     = phaseStyle{Class: "unknown", Fill: "#e2e3e5", Stroke: "#6c757d"}
+
+	phaseStyles = []phaseStyle{succeededStyle, failedStyle, progressingStyle, unknownStyle}
+)
+
+const dataFlowColor = "#1f6feb"
+
+func styleOfPhase(phase string) phaseStyle {
+	switch {
+	case phase == string(lsv1alpha1.InstallationPhases.Succeeded):
+		return succeededStyle
+	case isFailedPhase(phase):
+		return failedStyle
+	case len(phase) == 0:
+		return unknownStyle
+	default:
+		return progressingStyle
+	}
+}
+
+// IsGraphOutput returns whether the value of the output flag is handled by the GraphPrinter.
+func IsGraphOutput(output string) bool {
+	switch output {
+	case OutputDot, OutputMermaid, OutputHTML:
+		return true
+	default:
+		return false
+	}
+}
+
+// GraphPrinter prints a Graph as Graphviz dot, as Mermaid flowchart, or as self-contained html report in which the
+// yaml of each node can be expanded.
+type GraphPrinter struct {
+	format         string
+	showNamespaces bool
+}
+
+// NewGraphPrinter creates a printer for one of the formats dot, mermaid and html. If showNamespaces is set, the nodes
+// are labeled with namespace and name.
+func NewGraphPrinter(format string, showNamespaces bool) (*GraphPrinter, error) {
+	if !IsGraphOutput(format) {
+		return nil, fmt.Errorf("unknown graph output format %q", format)
+	}
+	return &GraphPrinter{format: format, showNamespaces: showNamespaces}, nil
+}
+
+// Print writes the graph in the format of the printer.
+func (p *GraphPrinter) Print(w io.Writer, g *Graph) error {
+	switch p.format {
+	case OutputDot:
+		_, err := io.WriteString(w, p.dot(g))
+		return err
+	case OutputMermaid:
+		_, err := io.WriteString(w, p.mermaid(g))
+		return err
+	default:
+		return p.html(w, g)
+	}
+}
+
+func (p *GraphPrinter) name(node *GraphNode) string {
+	if p.showNamespaces {
+		return fmt.Sprintf("%s/%s", node.Namespace, node.Name)
+	}
+	return node.Name
+}
+
+func formatGraphPhase(node *GraphNode) string {
+	phase := node.Phase
+	if len(phase) == 0 {
+		phase = "no phase"
+	}
+	if node.Outdated {
+		phase += " (outdated)"
+	}
+	return phase
+}
+
+func (p *GraphPrinter) dot(g *Graph) string {
+	shapes := map[string]string{
+		"Installation": "box",
+		"Execution":    "ellipse",
+		"DeployItem":   "component",
+	}
+
+	out := strings.Builder{}
+	out.WriteString("digraph installations {\n")
+	out.WriteString("\tnode [style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	out.WriteString("\tedge [fontname=\"Helvetica\"];\n")
+	for _, node := range g.Nodes {
+		style := styleOfPhase(node.Phase)
+		label := strings.Join([]string{node.Kind, p.name(node), formatGraphPhase(node)}, "\n")
+		out.WriteString(fmt.Sprintf("\t%s [label=%s, shape=%s, fillcolor=%q, color=%q", node.ID, dotQuote(label), shapes[node.Kind], style.Fill, style.Stroke))
+		if len(node.LastError) != 0 {
+			out.WriteString(fmt.Sprintf(", tooltip=%s", dotQuote(node.LastError)))
+		}
+		out.WriteString("];\n")
+	}
+	for _, edge := range g.Edges {
+		if !edge.DataFlow {
+			out.WriteString(fmt.Sprintf("\t%s -> %s;\n", edge.From, edge.To))
+			continue
+		}
+		out.WriteString(fmt.Sprintf("\t%s -> %s [style=dashed, color=%q, fontcolor=%q, label=%s, constraint=false];\n",
+			edge.From, edge.To, dataFlowColor, dataFlowColor, dotQuote(edge.Label)))
+	}
+	out.WriteString("}\n")
+	return out.String()
+}
+
+// dotQuote returns a quoted dot string. Line breaks are kept as escaped newlines.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func (p *GraphPrinter) mermaid(g *Graph) string {
+	shapes := map[string][2]string{
+		"Installation": {"[", "]"},
+		"Execution":    {"([", "])"},
+		"DeployItem":   {"[[", "]]"},
+	}
+
+	out := strings.Builder{}
+	out.WriteString("flowchart TD\n")
+	nodesByClass := map[string][]string{}
+	for _, node := range g.Nodes {
+		label := strings.Join([]string{node.Kind, p.name(node), formatGraphPhase(node)}, "<br/>")
+		shape := shapes[node.Kind]
+		out.WriteString(fmt.Sprintf("\t%s%s%s%s\n", node.ID, shape[0], mermaidQuote(label), shape[1]))
+		class := styleOfPhase(node.Phase).Class
+		nodesByClass[class] = append(nodesByClass[class], node.ID)
+	}
+	dataFlowEdges := []string{}
+	for i, edge := range g.Edges {
+		if !edge.DataFlow {
+			out.WriteString(fmt.Sprintf("\t%s --> %s\n", edge.From, edge.To))
+			continue
+		}
+		out.WriteString(fmt.Sprintf("\t%s -.->|%s| %s\n", edge.From, mermaidQuote(edge.Label), edge.To))
+		dataFlowEdges = append(dataFlowEdges, fmt.Sprint(i))
+	}
+	for _, style := range phaseStyles {
+		if len(nodesByClass[style.Class]) == 0 {
+			continue
+		}
+		out.WriteString(fmt.Sprintf("\tclassDef %s fill:%s,stroke:%s\n", style.Class, style.Fill, style.Stroke))
+		out.WriteString(fmt.Sprintf("\tclass %s %s\n", strings.Join(nodesByClass[style.Class], ","), style.Class))
+	}
+	if len(dataFlowEdges) != 0 {
+		out.WriteString(fmt.Sprintf("\tlinkStyle %s stroke:%s,color:%s\n", strings.Join(dataFlowEdges, ","), dataFlowColor, dataFlowColor))
+	}
+	return out.String()
+}
+
+// mermaidQuote returns a quoted mermaid label. Quotes are replaced by their entity code, because mermaid strings
+// cannot contain escaped quotes.
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+// htmlNode is a node of the html report together with the data flow edges of the node.
+type htmlNode struct {
+	*GraphNode
+	Label    string
+	Style    phaseStyle
+	Imports  []string
+	Exports  []string
+	Children []*htmlNode
+}
+
+type htmlReport struct {
+	Generated string
+	Styles    []phaseStyle
+	Roots     []*htmlNode
+}
+
+func (p *GraphPrinter) html(w io.Writer, g *Graph) error {
+	nodes := map[string]*GraphNode{}
+	for _, node := range g.Nodes {
+		nodes[node.ID] = node
+	}
+	imports := map[string][]string{}
+	exports := map[string][]string{}
+	for _, edge := range g.Edges {
+		if edge.DataFlow {
+			imports[edge.To] = append(imports[edge.To], fmt.Sprintf("%s from %s", edge.Label, p.name(nodes[edge.From])))
+			exports[edge.From] = append(exports[edge.From], fmt.Sprintf("%s to %s", edge.Label, p.name(nodes[edge.To])))
+		}
+	}
+
+	var convert func(node *GraphNode) *htmlNode
+	convert = func(node *GraphNode) *htmlNode {
+		converted := &htmlNode{
+			GraphNode: node,
+			Label:     p.name(node),
+			Style:     styleOfPhase(node.Phase),
+			Imports:   imports[node.ID],
+			Exports:   exports[node.ID],
+		}
+		for _, child := range node.Children {
+			converted.Children = append(converted.Children, convert(child))
+		}
+		return converted
+	}
+
+	report := &htmlReport{
+		Generated: time.Now().UTC().Format(time.RFC3339),
+		Styles:    phaseStyles,
+	}
+	for _, root := range g.Roots {
+		report.Roots = append(report.Roots, convert(root))
+	}
+	return htmlReportTemplate.Execute(w, report)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Landscaper installations</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; margin: 2em; }
+ul { list-style: none; padding-left: 1.5em; border-left: 1px solid #ccc; }
+ul.roots { padding-left: 0; border-left: none; }
+li { margin: 0.3em 0; }
+summary { cursor: pointer; }
+.phase { display: inline-block; padding: 0 0.4em; border: 1px solid; border-radius: 0.3em; font-size: 0.9em; }
+{{- range .Styles }}
+.{{ .Class }} { background: {{ .Fill }}; border-color: {{ .Stroke }}; }
+{{- end }}
+.kind { color: #555; }
+.error { color: #dc3545; margin: 0.3em 0; }
+.dataflow { color: ` + dataFlowColor + `; margin: 0.3em 0; }
+pre { background: #f6f8fa; padding: 0.8em; overflow: auto; }
+</style>
+</head>
+<body>
+<h1>Landscaper installations</h1>
+<p>Generated at {{ .Generated }}.</p>
+{{- define "node" }}
+<li>
+<details>
+<summary><span class="phase {{ .Style.Class }}">{{ if .Phase }}{{ .Phase }}{{ else }}no phase{{ end }}{{ if .Outdated }} (outdated){{ end }}</span> <span class="kind">{{ .Kind }}</span> {{ .Label }}</summary>
+{{- if .LastError }}
+<div class="error">Last error: {{ .LastError }}</div>
+{{- end }}
+{{- range .Imports }}
+<div class="dataflow">Imports {{ . }}</div>
+{{- end }}
+{{- range .Exports }}
+<div class="dataflow">Exports {{ . }}</div>
+{{- end }}
+<pre>{{ .Details }}</pre>
+</details>
+{{- if .Children }}
+<ul>
+{{- range .Children }}{{ template "node" . }}{{ end }}
+</ul>
+{{- end }}
+</li>
+{{- end }}
+<ul class="roots">
+{{- range .Roots }}{{ template "node" . }}{{ end }}
+</ul>
+</body>
+</html>
+`))
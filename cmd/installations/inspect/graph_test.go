@@ -0,0 +1,112 @@
+package tree
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGraph(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	serverDeployItem := installationTrees[0].SubInstallations[1].Execution.DeployItems[0].DeployItem
+	serverDeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Failed
+	serverDeployItem.Status.LastError = &lsv1alpha1.Error{Message: `chart "server" not found`}
+
+	t.Run("Graph of installation tree", func(t *testing.T) {
+		graph, err := NewTransformer(false, false, false, false).TransformToGraph(installationTrees)
+		assert.NoError(t, err)
+		assert.Len(t, graph.Roots, 1)
+		assert.Len(t, graph.Nodes, 7)
+		assert.Len(t, graph.Edges, 6)
+		for _, edge := range graph.Edges {
+			assert.False(t, edge.DataFlow)
+		}
+		assert.Equal(t, "n6", graph.Nodes[6].ID)
+		assert.Equal(t, serverDeployItem.Name, graph.Nodes[6].Name)
+		assert.Equal(t, `chart "server" not found`, graph.Nodes[6].LastError)
+	})
+
+	t.Run("Configurations and import values are redacted in the details", func(t *testing.T) {
+		inst := installationTrees[0].Installation.DeepCopy()
+		inst.Spec.ImportDataMappings = map[string]lsv1alpha1.AnyJSON{"password": lsv1alpha1.NewAnyJSON([]byte(`"secret-value"`))}
+		inst.Annotations = map[string]string{"kubectl.kubernetes.io/last-applied-configuration": `{"spec": {"importDataMappings": {"password": "secret-value"}}}`}
+		trees := []*InstallationTree{{Installation: inst, SubInstallations: installationTrees[0].SubInstallations}}
+
+		graph, err := NewTransformer(false, false, false, false).TransformToGraph(trees)
+		assert.NoError(t, err)
+		assert.NotContains(t, graph.Nodes[0].Details, "secret-value")
+		assert.Contains(t, graph.Nodes[0].Details, "password: <redacted>")
+		assert.Contains(t, graph.Nodes[0].Details, "kubectl.kubernetes.io/last-applied-configuration: <redacted>")
+		assert.Equal(t, "Execution", graph.Nodes[2].Kind)
+		assert.Contains(t, graph.Nodes[2].Details, "deployItemsCompressed: <redacted>")
+		assert.Equal(t, "DeployItem", graph.Nodes[6].Kind)
+		assert.Contains(t, graph.Nodes[6].Details, "config: <redacted>")
+		assert.NotContains(t, graph.Nodes[6].Details, "manifests:")
+		// the objects are not changed
+		assert.NotNil(t, serverDeployItem.Spec.Configuration)
+
+		exec := &lsv1alpha1.Execution{}
+		exec.Spec.DeployItems = lsv1alpha1.DeployItemTemplateList{{Name: "deploy", Configuration: &runtime.RawExtension{Raw: []byte(`{"password": "secret-value"}`)}}}
+		redacted, err := redactObject(exec)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"deployItemsCompressed": RedactedValue}, redacted["spec"])
+	})
+
+	// the ingress installation exports the ingress class imported by its sibling, the server installation
+	graph, err := NewTransformer(false, false, false, false).WithDataFlow(true).TransformToGraph(installationTrees)
+	assert.NoError(t, err)
+
+	t.Run("Data flow edges", func(t *testing.T) {
+		assert.Len(t, graph.Edges, 7)
+		assert.Equal(t, &GraphEdge{From: "n1", To: "n4", DataFlow: true, Label: "myIngressClass"}, graph.Edges[6])
+	})
+
+	t.Run("Dot", func(t *testing.T) {
+		printer, err := NewGraphPrinter(OutputDot, false)
+		assert.NoError(t, err)
+		out := &bytes.Buffer{}
+		assert.NoError(t, printer.Print(out, graph))
+		assert.Contains(t, out.String(), `n0 [label="Installation\nmy-aggregation\nSucceeded", shape=box, fillcolor="#d4edda", color="#28a745"];`)
+		assert.Contains(t, out.String(), `n6 [label="DeployItem\nserver-gw64l-deploy-7mhc2\nFailed", shape=component, fillcolor="#f8d7da", color="#dc3545", tooltip="chart \"server\" not found"];`)
+		assert.Contains(t, out.String(), "\tn0 -> n1;\n")
+		assert.Contains(t, out.String(), `n1 -> n4 [style=dashed, color="#1f6feb", fontcolor="#1f6feb", label="myIngressClass", constraint=false];`)
+	})
+
+	t.Run("Mermaid", func(t *testing.T) {
+		printer, err := NewGraphPrinter(OutputMermaid, true)
+		assert.NoError(t, err)
+		out := &bytes.Buffer{}
+		assert.NoError(t, printer.Print(out, graph))
+		assert.Contains(t, out.String(), `n2(["Execution<br/>inttest/ingress-hhsjf<br/>Succeeded"])`)
+		assert.Contains(t, out.String(), "\tn1 -.->|\"myIngressClass\"| n4\n")
+		assert.Contains(t, out.String(), "\tclass n0,n1,n2,n3,n4,n5 succeeded\n")
+		assert.Contains(t, out.String(), "\tclass n6 failed\n")
+		assert.Contains(t, out.String(), "\tlinkStyle 6 stroke:#1f6feb,color:#1f6feb\n")
+	})
+
+	t.Run("Html", func(t *testing.T) {
+		printer, err := NewGraphPrinter(OutputHTML, false)
+		assert.NoError(t, err)
+		out := &bytes.Buffer{}
+		assert.NoError(t, printer.Print(out, graph))
+		assert.Contains(t, out.String(), `<span class="phase failed">Failed</span> <span class="kind">DeployItem</span> server-gw64l-deploy-7mhc2</summary>`)
+		assert.Contains(t, out.String(), `<div class="error">Last error: chart &#34;server&#34; not found</div>`)
+		assert.Contains(t, out.String(), `<div class="dataflow">Imports myIngressClass from ingress-hhsjf</div>`)
+		assert.Contains(t, out.String(), `.failed { background: #f8d7da; border-color: #dc3545; }`)
+	})
+
+	_, err = NewGraphPrinter("svg", false)
+	assert.Error(t, err)
+}
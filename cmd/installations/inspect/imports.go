@@ -0,0 +1,393 @@
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	lsv1alpha1helper "github.com/gardener/landscaper/apis/core/v1alpha1/helper"
+	"github.com/gardener/landscaper/pkg/landscaper/installations"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// RedactedValue replaces the content of secrets and target configurations if secrets are not shown.
+const RedactedValue = "<redacted>"
+
+const (
+	ValueTypeData       = "data"
+	ValueTypeTarget     = "target"
+	ValueTypeTargetList = "targetList"
+	ValueTypeTargetMap  = "targetMap"
+)
+
+// ResolvedValue is an import or export of an installation together with the decoded content of its backing objects.
+type ResolvedValue struct {
+	// Name is the name of the import or export.
+	Name string `json:"name"`
+	// Type is one of data, target, targetList or targetMap.
+	Type string `json:"type"`
+	// Sources are the objects backing the value, e.g. "DataObject inttest/abc" or "Secret inttest/creds#key".
+	Sources []string `json:"sources"`
+	// Value is the decoded content. Secrets and target configurations are redacted unless secrets are shown.
+	Value interface{} `json:"value,omitempty"`
+	// Error is set if the backing objects cannot be resolved, e.g. because an export has not been created yet.
+	Error string `json:"error,omitempty"`
+}
+
+// ValueResolver resolves the imports and exports of an installation to DataObjects, Targets, Secrets and ConfigMaps.
+type ValueResolver struct {
+	K8sClient   client.Client
+	ShowSecrets bool
+}
+
+// ContextName returns the context in which the DataObjects and Targets of the imports and exports of an installation
+// are stored. Root installations have an empty context, subinstallations the context of their parent installation.
+func ContextName(inst *lsv1alpha1.Installation) string {
+	if installations.IsRootInstallation(inst) {
+		return ""
+	}
+	return lsv1alpha1helper.DataObjectSourceFromInstallationName(installations.GetParentInstallationName(inst))
+}
+
+// ResolveImports resolves spec.imports of the installation.
+func (r *ValueResolver) ResolveImports(ctx context.Context, inst *lsv1alpha1.Installation) []*ResolvedValue {
+	contextName := ContextName(inst)
+	values := []*ResolvedValue{}
+
+	for _, dataImport := range inst.Spec.Imports.Data {
+		value := &ResolvedValue{Name: dataImport.Name, Type: ValueTypeData}
+		var err error
+		switch {
+		case len(dataImport.DataRef) != 0:
+			err = r.resolveDataObject(ctx, value, inst.Namespace, lsv1alpha1helper.GenerateDataObjectName(contextName, dataImport.DataRef))
+		case dataImport.SecretRef != nil:
+			err = r.resolveSecret(ctx, value, inst.Namespace, dataImport.SecretRef.Name, dataImport.SecretRef.Key)
+		case dataImport.ConfigMapRef != nil:
+			err = r.resolveConfigMap(ctx, value, inst.Namespace, dataImport.ConfigMapRef.Name, dataImport.ConfigMapRef.Key)
+		default:
+			err = fmt.Errorf("import defines neither a dataRef, a secretRef nor a configMapRef")
+		}
+		values = append(values, withError(value, err))
+	}
+
+	for _, targetImport := range inst.Spec.Imports.Targets {
+		values = append(values, r.resolveTargetImport(ctx, inst, contextName, targetImport))
+	}
+
+	return values
+}
+
+// ResolveExports resolves spec.exports of the installation. Exports are stored in the same context as the imports.
+func (r *ValueResolver) ResolveExports(ctx context.Context, inst *lsv1alpha1.Installation) []*ResolvedValue {
+	contextName := ContextName(inst)
+	values := []*ResolvedValue{}
+
+	for _, dataExport := range inst.Spec.Exports.Data {
+		value := &ResolvedValue{Name: dataExport.Name, Type: ValueTypeData}
+		err := r.resolveDataObject(ctx, value, inst.Namespace, lsv1alpha1helper.GenerateDataObjectName(contextName, dataExport.DataRef))
+		values = append(values, withError(value, err))
+	}
+
+	for _, targetExport := range inst.Spec.Exports.Targets {
+		value := &ResolvedValue{Name: targetExport.Name, Type: ValueTypeTarget}
+		target, err := r.getTarget(ctx, value, inst.Namespace, lsv1alpha1helper.GenerateDataObjectName(contextName, targetExport.Target))
+		if err == nil {
+			value.Value, err = r.decodeTarget(ctx, value, target)
+		}
+		values = append(values, withError(value, err))
+	}
+
+	return values
+}
+
+func (r *ValueResolver) resolveTargetImport(ctx context.Context, inst *lsv1alpha1.Installation, contextName string, targetImport lsv1alpha1.TargetImport) *ResolvedValue {
+	switch {
+	case len(targetImport.Target) != 0:
+		value := &ResolvedValue{Name: targetImport.Name, Type: ValueTypeTarget}
+		target, err := r.getTarget(ctx, value, inst.Namespace, lsv1alpha1helper.GenerateDataObjectName(contextName, targetImport.Target))
+		if err == nil {
+			value.Value, err = r.decodeTarget(ctx, value, target)
+		}
+		return withError(value, err)
+
+	case targetImport.Targets != nil:
+		value := &ResolvedValue{Name: targetImport.Name, Type: ValueTypeTargetList}
+		targets := []interface{}{}
+		for _, targetName := range targetImport.Targets {
+			target, err := r.getTarget(ctx, value, inst.Namespace, lsv1alpha1helper.GenerateDataObjectName(contextName, targetName))
+			if err != nil {
+				return withError(value, err)
+			}
+			decoded, err := r.decodeTarget(ctx, value, target)
+			if err != nil {
+				return withError(value, err)
+			}
+			targets = append(targets, decoded)
+		}
+		value.Value = targets
+		return value
+
+	case len(targetImport.TargetListReference) != 0:
+		value := &ResolvedValue{Name: targetImport.Name, Type: ValueTypeTargetList}
+		targetList, err := r.listTargetsByReference(ctx, value, inst, contextName, targetImport.TargetListReference)
+		if err != nil {
+			return withError(value, err)
+		}
+		targets := []interface{}{}
+		for i := range targetList {
+			decoded, err := r.decodeTarget(ctx, value, &targetList[i])
+			if err != nil {
+				return withError(value, err)
+			}
+			targets = append(targets, decoded)
+		}
+		value.Value = targets
+		return value
+
+	case targetImport.TargetMap != nil:
+		value := &ResolvedValue{Name: targetImport.Name, Type: ValueTypeTargetMap}
+		targets := map[string]interface{}{}
+		for key, targetName := range targetImport.TargetMap {
+			target, err := r.getTarget(ctx, value, inst.Namespace, lsv1alpha1helper.GenerateDataObjectName(contextName, targetName))
+			if err != nil {
+				return withError(value, err)
+			}
+			if targets[key], err = r.decodeTarget(ctx, value, target); err != nil {
+				return withError(value, err)
+			}
+		}
+		sort.Strings(value.Sources)
+		value.Value = targets
+		return value
+
+	case len(targetImport.TargetMapReference) != 0:
+		value := &ResolvedValue{Name: targetImport.Name, Type: ValueTypeTargetMap}
+		targetList, err := r.listTargetsByReference(ctx, value, inst, contextName, targetImport.TargetMapReference)
+		if err != nil {
+			return withError(value, err)
+		}
+		targets := map[string]interface{}{}
+		for i := range targetList {
+			key := targetList[i].Labels[lsv1alpha1.DataObjectTargetMapKeyLabel]
+			if targets[key], err = r.decodeTarget(ctx, value, &targetList[i]); err != nil {
+				return withError(value, err)
+			}
+		}
+		value.Value = targets
+		return value
+
+	default:
+		value := &ResolvedValue{Name: targetImport.Name, Type: ValueTypeTarget}
+		return withError(value, fmt.Errorf("import defines no target"))
+	}
+}
+
+func (r *ValueResolver) resolveDataObject(ctx context.Context, value *ResolvedValue, namespace, name string) error {
+	value.Sources = append(value.Sources, fmt.Sprintf("DataObject %s/%s", namespace, name))
+
+	dataObject := &lsv1alpha1.DataObject{}
+	if err := r.K8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, dataObject); err != nil {
+		return fmt.Errorf("cannot get data object %s: %w", name, err)
+	}
+
+	var err error
+	value.Value, err = decodeJSON(dataObject.Data.RawMessage)
+	if err != nil {
+		return fmt.Errorf("cannot decode data object %s: %w", name, err)
+	}
+	return nil
+}
+
+func (r *ValueResolver) resolveSecret(ctx context.Context, value *ResolvedValue, namespace, name, key string) error {
+	value.Sources = append(value.Sources, formatSource("Secret", namespace, name, key))
+
+	secret := &corev1.Secret{}
+	if err := r.K8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, secret); err != nil {
+		return fmt.Errorf("cannot get secret %s: %w", name, err)
+	}
+
+	data, err := selectKey(secret.Data, key, "secret", name)
+	if err != nil {
+		return err
+	}
+	if !r.ShowSecrets {
+		value.Value = RedactedValue
+		return nil
+	}
+	value.Value = decodeData(data)
+	return nil
+}
+
+func (r *ValueResolver) resolveConfigMap(ctx context.Context, value *ResolvedValue, namespace, name, key string) error {
+	value.Sources = append(value.Sources, formatSource("ConfigMap", namespace, name, key))
+
+	configMap := &corev1.ConfigMap{}
+	if err := r.K8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, configMap); err != nil {
+		return fmt.Errorf("cannot get config map %s: %w", name, err)
+	}
+
+	data := map[string][]byte{}
+	for k, v := range configMap.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range configMap.BinaryData {
+		data[k] = v
+	}
+
+	selected, err := selectKey(data, key, "config map", name)
+	if err != nil {
+		return err
+	}
+	value.Value = decodeData(selected)
+	return nil
+}
+
+func (r *ValueResolver) getTarget(ctx context.Context, value *ResolvedValue, namespace, name string) (*lsv1alpha1.Target, error) {
+	value.Sources = append(value.Sources, fmt.Sprintf("Target %s/%s", namespace, name))
+
+	target := &lsv1alpha1.Target{}
+	if err := r.K8sClient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, target); err != nil {
+		return nil, fmt.Errorf("cannot get target %s: %w", name, err)
+	}
+	return target, nil
+}
+
+// listTargetsByReference lists the targets which the landscaper has created for a targetListReference or
+// targetMapReference in the context of the installation, analogous to the landscaper's import resolution.
+func (r *ValueResolver) listTargetsByReference(ctx context.Context, value *ResolvedValue, inst *lsv1alpha1.Installation, contextName, reference string) ([]lsv1alpha1.Target, error) {
+	selector := labels.NewSelector()
+	addRequirement := func(key string, op selection.Operator, values ...string) error {
+		requirement, err := labels.NewRequirement(key, op, values)
+		if err != nil {
+			return fmt.Errorf("unable to construct label selector: %w", err)
+		}
+		selector = selector.Add(*requirement)
+		return nil
+	}
+
+	if err := addRequirement(lsv1alpha1.DataObjectKeyLabel, selection.Equals, reference); err != nil {
+		return nil, err
+	}
+	if err := addRequirement(lsv1alpha1.DataObjectSourceTypeLabel, selection.Equals, string(lsv1alpha1.ImportDataObjectSourceType)); err != nil {
+		return nil, err
+	}
+	if len(contextName) != 0 {
+		if err := addRequirement(lsv1alpha1.DataObjectContextLabel, selection.Equals, contextName); err != nil {
+			return nil, err
+		}
+		if err := addRequirement(lsv1alpha1.DataObjectJobIDLabel, selection.Equals, inst.Status.JobID); err != nil {
+			return nil, err
+		}
+	} else {
+		// top-level targets have no context label
+		if err := addRequirement(lsv1alpha1.DataObjectContextLabel, selection.DoesNotExist); err != nil {
+			return nil, err
+		}
+	}
+
+	targetList := &lsv1alpha1.TargetList{}
+	if err := r.K8sClient.List(ctx, targetList, client.InNamespace(inst.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("cannot list targets for reference %s: %w", reference, err)
+	}
+
+	sort.Slice(targetList.Items, func(i, j int) bool {
+		return targetList.Items[i].Name < targetList.Items[j].Name
+	})
+	for _, target := range targetList.Items {
+		value.Sources = append(value.Sources, fmt.Sprintf("Target %s/%s", target.Namespace, target.Name))
+	}
+	return targetList.Items, nil
+}
+
+// decodeTarget returns the type and the configuration of a target. The configuration is redacted unless secrets are
+// shown, because it usually contains credentials like a kubeconfig. The content of a secret referenced by the target
+// is resolved if secrets are shown.
+func (r *ValueResolver) decodeTarget(ctx context.Context, value *ResolvedValue, target *lsv1alpha1.Target) (interface{}, error) {
+	decoded := map[string]interface{}{
+		"type": string(target.Spec.Type),
+	}
+
+	if target.Spec.SecretRef != nil {
+		decoded["secretRef"] = target.Spec.SecretRef
+		secretValue := &ResolvedValue{}
+		if err := r.resolveSecret(ctx, secretValue, target.Namespace, target.Spec.SecretRef.Name, target.Spec.SecretRef.Key); err != nil {
+			return nil, err
+		}
+		value.Sources = append(value.Sources, secretValue.Sources...)
+		decoded["configuration"] = secretValue.Value
+		return decoded, nil
+	}
+
+	if target.Spec.Configuration != nil {
+		if !r.ShowSecrets {
+			decoded["configuration"] = RedactedValue
+			return decoded, nil
+		}
+		configuration, err := decodeJSON(target.Spec.Configuration.RawMessage)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode configuration of target %s: %w", target.Name, err)
+		}
+		decoded["configuration"] = configuration
+	}
+
+	return decoded, nil
+}
+
+func withError(value *ResolvedValue, err error) *ResolvedValue {
+	if err != nil {
+		value.Value = nil
+		value.Error = err.Error()
+	}
+	return value
+}
+
+func formatSource(kind, namespace, name, key string) string {
+	if len(key) == 0 {
+		return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+	}
+	return fmt.Sprintf("%s %s/%s#%s", kind, namespace, name, key)
+}
+
+// selectKey returns the value of the key, or all data as JSON object if no key is given.
+func selectKey(data map[string][]byte, key, kind, name string) ([]byte, error) {
+	if len(key) == 0 {
+		all := map[string]string{}
+		for k, v := range data {
+			all[k] = string(v)
+		}
+		return json.Marshal(all)
+	}
+
+	selected, ok := data[key]
+	if !ok {
+		return nil, fmt.Errorf("%s %s has no key %s", kind, name, key)
+	}
+	return selected, nil
+}
+
+func decodeJSON(raw []byte) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// decodeData decodes the content of a secret or config map key. Like the landscaper, it interprets the content as
+// yaml or json and falls back to a plain string.
+func decodeData(data []byte) interface{} {
+	var decoded interface{}
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return string(data)
+	}
+	return decoded
+}
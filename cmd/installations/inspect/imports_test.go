@@ -0,0 +1,63 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestResolveValues(t *testing.T) {
+	ctx := context.Background()
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	getInstallation := func(name string) *lsv1alpha1.Installation {
+		inst := &lsv1alpha1.Installation{}
+		assert.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: name, Namespace: "inttest"}, inst))
+		return inst
+	}
+
+	t.Run("Imports of root installation with redacted secrets", func(t *testing.T) {
+		resolver := ValueResolver{K8sClient: fakeClient}
+		values := resolver.ResolveImports(ctx, getInstallation("my-aggregation"))
+		assert.Len(t, values, 2)
+
+		assert.Equal(t, "aggNamespace", values[0].Name)
+		assert.Equal(t, []string{"ConfigMap inttest/my-imports#namespace"}, values[0].Sources)
+		assert.Equal(t, "inttest2", values[0].Value)
+
+		assert.Equal(t, "aggCluster", values[1].Name)
+		assert.Equal(t, []string{"Target inttest/my-cluster", "Secret inttest/my-cluster-kubeconfig#config"}, values[1].Sources)
+		assert.Equal(t, RedactedValue, values[1].Value.(map[string]interface{})["configuration"])
+	})
+
+	t.Run("Imports of root installation with shown secrets", func(t *testing.T) {
+		resolver := ValueResolver{K8sClient: fakeClient, ShowSecrets: true}
+		values := resolver.ResolveImports(ctx, getInstallation("my-aggregation"))
+		assert.Equal(t, map[string]interface{}{"kubeconfig": "apiVersion: v1\nkind: Config\n"},
+			values[1].Value.(map[string]interface{})["configuration"])
+	})
+
+	t.Run("Context-scoped imports and exports of subinstallation", func(t *testing.T) {
+		resolver := ValueResolver{K8sClient: fakeClient}
+		ingress := getInstallation("ingress-hhsjf")
+		assert.Equal(t, "Inst.my-aggregation", ContextName(ingress))
+
+		imports := resolver.ResolveImports(ctx, ingress)
+		assert.Len(t, imports, 2)
+		assert.Equal(t, "namespace", imports[0].Name)
+		assert.NotEmpty(t, imports[0].Error)
+		assert.Nil(t, imports[0].Value)
+
+		exports := resolver.ResolveExports(ctx, ingress)
+		assert.Len(t, exports, 1)
+		assert.Equal(t, "ingressClass", exports[0].Name)
+		assert.Equal(t, []string{"DataObject inttest/nlorvbmwrrbyt4lpirj7ecttntrog2ly"}, exports[0].Sources)
+		assert.Equal(t, "nginx", exports[0].Value)
+		assert.Empty(t, exports[0].Error)
+	})
+}
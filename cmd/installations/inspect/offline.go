@@ -0,0 +1,147 @@
+package tree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// NewOfflineClientFromFile builds an in-memory client from a yaml or json dump, e.g. the output of
+// "kubectl get installations,executions,deployitems -o yaml". The file may contain multiple documents and lists.
+// If path is "-", the dump is read from stdin.
+func NewOfflineClientFromFile(scheme *runtime.Scheme, path string) (client.WithWatch, error) {
+	loader := newObjectLoader(scheme)
+	if err := loader.loadFile(path); err != nil {
+		return nil, err
+	}
+	return loader.build(), nil
+}
+
+// NewOfflineClientFromDir builds an in-memory client from all yaml and json files of a directory and its
+// subdirectories, e.g. an unpacked support bundle.
+func NewOfflineClientFromDir(scheme *runtime.Scheme, dir string) (client.WithWatch, error) {
+	loader := newObjectLoader(scheme)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isManifestFile(path) {
+			return nil
+		}
+		return loader.loadFile(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loader.build(), nil
+}
+
+// objectLoader decodes objects of all kinds that are known to the scheme. Objects of other kinds are ignored, so that
+// dumps may contain arbitrary resources. If an object occurs multiple times, the last occurrence wins.
+type objectLoader struct {
+	scheme  *runtime.Scheme
+	objects map[string]client.Object
+	keys    []string
+}
+
+func newObjectLoader(scheme *runtime.Scheme) *objectLoader {
+	return &objectLoader{
+		scheme:  scheme,
+		objects: map[string]client.Object{},
+	}
+}
+
+func (l *objectLoader) loadFile(path string) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot read file %s: %w", path, err)
+	}
+
+	decoder := k8syaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		u := &unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("cannot decode file %s: %w", path, err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+
+		if u.IsList() {
+			list, err := u.ToList()
+			if err != nil {
+				return fmt.Errorf("cannot decode list in file %s: %w", path, err)
+			}
+			for i := range list.Items {
+				if err := l.add(&list.Items[i]); err != nil {
+					return fmt.Errorf("cannot decode object in file %s: %w", path, err)
+				}
+			}
+			continue
+		}
+
+		if err := l.add(u); err != nil {
+			return fmt.Errorf("cannot decode object in file %s: %w", path, err)
+		}
+	}
+}
+
+func (l *objectLoader) add(u *unstructured.Unstructured) error {
+	gvk := u.GroupVersionKind()
+	obj, err := l.scheme.New(gvk)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return fmt.Errorf("cannot convert %s %s: %w", gvk.Kind, u.GetName(), err)
+	}
+	clientObj, ok := obj.(client.Object)
+	if !ok {
+		return nil
+	}
+
+	key := gvk.GroupKind().String() + "/" + types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}.String()
+	if _, ok := l.objects[key]; !ok {
+		l.keys = append(l.keys, key)
+	}
+	l.objects[key] = clientObj
+	return nil
+}
+
+func (l *objectLoader) build() client.WithWatch {
+	objects := make([]client.Object, 0, len(l.keys))
+	for _, key := range l.keys {
+		objects = append(objects, l.objects[key])
+	}
+	return fake.NewClientBuilder().WithScheme(l.scheme).WithObjects(objects...).Build()
+}
+
+func isManifestFile(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".yaml" || ext == ".yml" || ext == ".json"
+}
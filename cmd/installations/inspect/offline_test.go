@@ -0,0 +1,73 @@
+package tree
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+func TestOfflineClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, lsv1alpha1.AddToScheme(scheme))
+
+	files, err := filepath.Glob("./testdata/*.yaml")
+	assert.NoError(t, err)
+	documents := []string{}
+	items := []interface{}{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		documents = append(documents, string(data))
+
+		var item interface{}
+		assert.NoError(t, yaml.Unmarshal(data, &item))
+		items = append(items, item)
+	}
+	list, err := yaml.Marshal(map[string]interface{}{"apiVersion": "v1", "kind": "List", "items": items})
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	multiDocFile := filepath.Join(dir, "multidoc.yaml")
+	assert.NoError(t, os.WriteFile(multiDocFile, []byte(strings.Join(documents, "\n---\n")), 0600))
+	listFile := filepath.Join(dir, "list.yaml")
+	assert.NoError(t, os.WriteFile(listFile, list, 0600))
+
+	liveClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+	expectedTrees, err := (&Collector{K8sClient: liveClient}).CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+
+	for name, load := range map[string]func() (client.WithWatch, error){
+		"multi-doc file": func() (client.WithWatch, error) { return NewOfflineClientFromFile(scheme, multiDocFile) },
+		"list file":      func() (client.WithWatch, error) { return NewOfflineClientFromFile(scheme, listFile) },
+		"directory":      func() (client.WithWatch, error) { return NewOfflineClientFromDir(scheme, "./testdata") },
+	} {
+		t.Run(name, func(t *testing.T) {
+			offlineClient, err := load()
+			assert.NoError(t, err)
+
+			collector := Collector{
+				K8sClient: offlineClient,
+			}
+			installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "", "*")
+			assert.NoError(t, err)
+			assert.Len(t, installationTrees, 2)
+
+			installationTrees, err = collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+			assert.NoError(t, err)
+			assert.Equal(t, expectedTrees[0].Installation.Spec.Imports, installationTrees[0].Installation.Spec.Imports)
+			assert.Equal(t, expectedTrees[0].Installation.Status, installationTrees[0].Installation.Status)
+			assert.Equal(t, len(expectedTrees[0].SubInstallations), len(installationTrees[0].SubInstallations))
+			assert.Equal(t, len(expectedTrees[0].DeployItems()), len(installationTrees[0].DeployItems()))
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package tree
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	lsv1alpha1helper "github.com/gardener/landscaper/apis/core/v1alpha1/helper"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Orphan is an execution, deployItem or DataObject whose owning object does not exist anymore, e.g. after a partial
+// force-delete.
+type Orphan struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Reason explains which owning object is missing.
+	Reason string `json:"reason"`
+	// Object is the orphaned object.
+	Object client.Object `json:"-"`
+	// owner is the missing owning object, with namespace and name only. It is nil if the object has no known owner.
+	owner client.Object
+}
+
+// CollectOrphans finds the orphaned objects of the namespace, or of all namespaces if namespace is "*". Like
+// CollectInstallationsInCluster, it relates the objects by the execution references of installations and by the
+// labels of deployItems and DataObjects:
+//   - executions which are not referenced by an installation and whose owning installation does not exist,
+//   - deployItems whose execution does not exist or is orphaned,
+//   - DataObjects whose context or source installation or execution does not exist.
+//
+// The orphans are sorted so that deployItems come before their executions, which is the order in which they can be
+// deleted.
+func (c *Collector) CollectOrphans(ctx context.Context, namespace string) ([]*Orphan, error) {
+	objects := newObjectIndex(c, time.Now())
+
+	listNamespace := namespace
+	if namespace == "*" {
+		listNamespace = ""
+	}
+	if err := objects.load(ctx, listNamespace); err != nil {
+		return nil, err
+	}
+
+	dataObjects := []*lsv1alpha1.DataObject{}
+	err := objects.list(ctx, listNamespace, "dataobjects", &lsv1alpha1.DataObjectList{}, func(list client.ObjectList) {
+		for i := range list.(*lsv1alpha1.DataObjectList).Items {
+			dataObjects = append(dataObjects, &list.(*lsv1alpha1.DataObjectList).Items[i])
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	referencedExecutions := map[string]bool{}
+	for _, inst := range objects.installationList {
+		if ref := inst.Status.ExecutionReference; ref != nil {
+			referencedExecutions[objectKey(ref.Namespace, ref.Name)] = true
+		}
+	}
+
+	deployItemOrphans := []*Orphan{}
+	executionOrphans := []*Orphan{}
+	orphanedExecutions := map[string]bool{}
+	for _, exec := range objects.executions {
+		key := objectKey(exec.Namespace, exec.Name)
+		if referencedExecutions[key] {
+			continue
+		}
+		owner := ownerInstallationName(exec)
+		if _, ok := objects.installations[objectKey(exec.Namespace, owner)]; owner != "" && ok {
+			continue
+		}
+
+		orphan := newOrphan(exec, "no installation references the execution", nil)
+		if owner != "" {
+			orphan = newOrphan(exec, fmt.Sprintf("owning installation %s does not exist", owner), missingInstallation(exec.Namespace, owner))
+		}
+		executionOrphans = append(executionOrphans, orphan)
+		orphanedExecutions[key] = true
+	}
+
+	for _, deployItem := range objects.deployItemList {
+		execName, ok := deployItem.Labels[lsv1alpha1.ExecutionManagedByLabel]
+		if !ok {
+			// deployItems without execution are not managed by the landscaper
+			continue
+		}
+		key := objectKey(deployItem.Namespace, execName)
+		if exec, ok := objects.executions[key]; !ok {
+			deployItemOrphans = append(deployItemOrphans, newOrphan(deployItem, fmt.Sprintf("execution %s does not exist", execName),
+				missingExecution(deployItem.Namespace, execName)))
+		} else if orphanedExecutions[key] {
+			// the deployItem is orphaned as long as the owner of its execution is missing
+			var owner client.Object
+			if name := ownerInstallationName(exec); name != "" {
+				owner = missingInstallation(exec.Namespace, name)
+			}
+			deployItemOrphans = append(deployItemOrphans, newOrphan(deployItem, fmt.Sprintf("execution %s is orphaned", execName), owner))
+		}
+	}
+
+	dataObjectOrphans := []*Orphan{}
+	for _, dataObject := range dataObjects {
+		if reason, owner := danglingReason(objects, dataObject); reason != "" {
+			dataObjectOrphans = append(dataObjectOrphans, newOrphan(dataObject, reason, owner))
+		}
+	}
+
+	orphans := []*Orphan{}
+	for _, group := range [][]*Orphan{deployItemOrphans, executionOrphans, dataObjectOrphans} {
+		sort.Slice(group, func(i, j int) bool {
+			return objectKey(group[i].Namespace, group[i].Name) < objectKey(group[j].Namespace, group[j].Name)
+		})
+		orphans = append(orphans, group...)
+	}
+	return orphans, nil
+}
+
+// danglingReason returns why a DataObject is dangling together with the missing object, or an empty string if its
+// context and source exist. DataObjects without context and source label, e.g. the ones created by users for root
+// installations, are never dangling.
+func danglingReason(objects *objectIndex, dataObject *lsv1alpha1.DataObject) (string, client.Object) {
+	if contextName, ok := dataObject.Labels[lsv1alpha1.DataObjectContextLabel]; ok {
+		if name, ok := strings.CutPrefix(contextName, lsv1alpha1helper.InstallationPrefix); ok {
+			if _, exists := objects.installations[objectKey(dataObject.Namespace, name)]; !exists {
+				return fmt.Sprintf("context installation %s does not exist", name), missingInstallation(dataObject.Namespace, name)
+			}
+		}
+	}
+
+	source := dataObject.Labels[lsv1alpha1.DataObjectSourceLabel]
+	if name, ok := strings.CutPrefix(source, lsv1alpha1helper.InstallationPrefix); ok {
+		if _, exists := objects.installations[objectKey(dataObject.Namespace, name)]; !exists {
+			return fmt.Sprintf("source installation %s does not exist", name), missingInstallation(dataObject.Namespace, name)
+		}
+	}
+	if name, ok := strings.CutPrefix(source, lsv1alpha1helper.ExecutionPrefix); ok {
+		if _, exists := objects.executions[objectKey(dataObject.Namespace, name)]; !exists {
+			return fmt.Sprintf("source execution %s does not exist", name), missingExecution(dataObject.Namespace, name)
+		}
+	}
+	return "", nil
+}
+
+// OwnerExists re-reads the missing owner of an orphan. The lists from which the orphans are determined are not taken
+// at the same time, so an owner that was created in between, e.g. by a running reconcile, can exist nevertheless.
+// Orphans without known owner are still orphaned.
+func (c *Collector) OwnerExists(ctx context.Context, orphan *Orphan) (bool, error) {
+	if orphan.owner == nil {
+		return false, nil
+	}
+	owner := orphan.owner.DeepCopyObject().(client.Object)
+	if err := c.K8sClient.Get(ctx, client.ObjectKeyFromObject(orphan.owner), owner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot get %s %s of %s %s: %w", strings.ToLower(KindOf(orphan.owner)), orphan.owner.GetName(),
+			strings.ToLower(orphan.Kind), orphan.Name, err)
+	}
+	return true, nil
+}
+
+func missingInstallation(namespace, name string) client.Object {
+	inst := &lsv1alpha1.Installation{}
+	inst.Namespace = namespace
+	inst.Name = name
+	return inst
+}
+
+func missingExecution(namespace, name string) client.Object {
+	exec := &lsv1alpha1.Execution{}
+	exec.Namespace = namespace
+	exec.Name = name
+	return exec
+}
+
+// ownerInstallationName returns the name of the installation in the owner references of the object.
+func ownerInstallationName(obj client.Object) string {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.Kind == "Installation" {
+			return ref.Name
+		}
+	}
+	return ""
+}
+
+func newOrphan(obj client.Object, reason string, owner client.Object) *Orphan {
+	return &Orphan{
+		Kind:      KindOf(obj),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Reason:    reason,
+		Object:    obj,
+		owner:     owner,
+	}
+}
@@ -0,0 +1,75 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCollectOrphans(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{K8sClient: fakeClient}
+	orphans, err := collector.CollectOrphans(context.TODO(), "*")
+	assert.NoError(t, err)
+	assert.Empty(t, orphans)
+
+	ctx := context.TODO()
+	meta := func(name string, labels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "inttest", Labels: labels}
+	}
+	orphanedExecution := &lsv1alpha1.Execution{ObjectMeta: meta("orphaned-exec", nil)}
+	orphanedExecution.OwnerReferences = []metav1.OwnerReference{{APIVersion: "landscaper.gardener.cloud/v1alpha1", Kind: "Installation", Name: "deleted-inst"}}
+	assert.NoError(t, fakeClient.Create(ctx, orphanedExecution))
+	assert.NoError(t, fakeClient.Create(ctx, &lsv1alpha1.DeployItem{ObjectMeta: meta("b-item", map[string]string{lsv1alpha1.ExecutionManagedByLabel: "orphaned-exec"})}))
+	assert.NoError(t, fakeClient.Create(ctx, &lsv1alpha1.DeployItem{ObjectMeta: meta("a-item", map[string]string{lsv1alpha1.ExecutionManagedByLabel: "deleted-exec"})}))
+	// deployItems without execution are not orphaned
+	assert.NoError(t, fakeClient.Create(ctx, &lsv1alpha1.DeployItem{ObjectMeta: meta("standalone-item", nil)}))
+	assert.NoError(t, fakeClient.Create(ctx, &lsv1alpha1.DataObject{ObjectMeta: meta("dangling-do", map[string]string{
+		lsv1alpha1.DataObjectContextLabel: "Inst.my-aggregation",
+		lsv1alpha1.DataObjectSourceLabel:  "Inst.deleted-inst",
+	})}))
+
+	orphans, err = collector.CollectOrphans(ctx, "inttest")
+	assert.NoError(t, err)
+	reasons := []string{}
+	for _, orphan := range orphans {
+		reasons = append(reasons, orphan.Kind+" "+orphan.Name+": "+orphan.Reason)
+	}
+	assert.Equal(t, []string{
+		"DeployItem a-item: execution deleted-exec does not exist",
+		"DeployItem b-item: execution orphaned-exec is orphaned",
+		"Execution orphaned-exec: owning installation deleted-inst does not exist",
+		"DataObject dangling-do: source installation deleted-inst does not exist",
+	}, reasons)
+	assert.Equal(t, "orphaned-exec", orphans[2].Object.GetName())
+
+	t.Run("Owners created after the collection are found before deleting", func(t *testing.T) {
+		for _, orphan := range orphans {
+			ownerExists, err := collector.OwnerExists(ctx, orphan)
+			assert.NoError(t, err)
+			assert.False(t, ownerExists, orphan.Name)
+		}
+
+		assert.NoError(t, fakeClient.Create(ctx, &lsv1alpha1.Installation{ObjectMeta: meta("deleted-inst", nil)}))
+		defer func() {
+			assert.NoError(t, fakeClient.Delete(ctx, &lsv1alpha1.Installation{ObjectMeta: meta("deleted-inst", nil)}))
+		}()
+		ownersExist := []bool{}
+		for _, orphan := range orphans {
+			ownerExists, err := collector.OwnerExists(ctx, orphan)
+			assert.NoError(t, err)
+			ownersExist = append(ownersExist, ownerExists)
+		}
+		assert.Equal(t, []bool{false, true, true, true}, ownersExist)
+	})
+
+	orphans, err = collector.CollectOrphans(ctx, "other")
+	assert.NoError(t, err)
+	assert.Empty(t, orphans)
+}
@@ -0,0 +1,156 @@
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	helmv1alpha1 "github.com/gardener/landscaper/apis/deployer/helm/v1alpha1"
+	"github.com/gardener/landscaper/pkg/landscaper/installations"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotations with which helm marks the objects of a release.
+const (
+	HelmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	HelmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+// OwnedObject identifies a kubernetes object deployed to a target cluster.
+type OwnedObject struct {
+	Kind string
+	Name string
+	// Namespace is empty for cluster scoped objects.
+	Namespace string
+	// HelmRelease and HelmReleaseNamespace are optional. If set, helm deployItems deploying this release are owners as
+	// well, even if the object is not listed in their managed resources.
+	HelmRelease          string
+	HelmReleaseNamespace string
+}
+
+// Owner is a deployItem managing an object, together with the objects above it.
+type Owner struct {
+	// MatchedBy tells how the deployItem was found, e.g. "managed resource" or "helm release ns/name".
+	MatchedBy string `json:"matchedBy"`
+	// Target is the namespace/name of the target of the deployItem.
+	Target string `json:"target,omitempty"`
+	// Chain contains the deployItem, its execution and its installation up to the root installation.
+	Chain []OwnerChainLink `json:"chain"`
+}
+
+// OwnerChainLink is an object of the chain from a deployItem to its root installation.
+type OwnerChainLink struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+}
+
+// FindOwners collects the deployItems which manage the object, i.e. whose provider status lists the object as managed
+// resource, or which deploy the helm release of the object. For each deployItem, the chain of its execution and
+// installations up to the root installation is resolved. Like CollectInstallationsInCluster, namespace "*" searches
+// all namespaces of the landscaper cluster.
+func (c *Collector) FindOwners(ctx context.Context, namespace string, obj OwnedObject) ([]*Owner, error) {
+	objects := newObjectIndex(c, time.Now())
+
+	listNamespace := namespace
+	if namespace == "*" {
+		listNamespace = ""
+	}
+	if err := objects.load(ctx, listNamespace); err != nil {
+		return nil, err
+	}
+
+	owners := []*Owner{}
+	for _, deployItem := range objects.deployItemList {
+		matchedBy, err := managesObject(deployItem, obj)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode deployitem %s: %w", deployItem.Name, err)
+		}
+		if len(matchedBy) == 0 {
+			continue
+		}
+
+		owner := &Owner{
+			MatchedBy: matchedBy,
+			Chain:     []OwnerChainLink{chainLink(deployItem)},
+		}
+		if deployItem.Spec.Target != nil {
+			owner.Target = objectKey(deployItem.Spec.Target.Namespace, deployItem.Spec.Target.Name)
+		}
+		owner.Chain = append(owner.Chain, objects.ownerChain(deployItem)...)
+		owners = append(owners, owner)
+	}
+	return owners, nil
+}
+
+// managesObject returns how the deployItem manages the object, or an empty string if it does not.
+func managesObject(deployItem *lsv1alpha1.DeployItem, obj OwnedObject) (string, error) {
+	refs, err := ManagedResourcesOf(deployItem)
+	if err != nil {
+		return "", err
+	}
+	for _, ref := range refs {
+		if strings.EqualFold(ref.Kind, obj.Kind) && ref.Name == obj.Name && ref.Namespace == obj.Namespace {
+			return "managed resource", nil
+		}
+	}
+
+	if len(obj.HelmRelease) == 0 || deployItem.Spec.Type != HelmDeployItemType || deployItem.Spec.Configuration == nil {
+		return "", nil
+	}
+	config := &helmv1alpha1.ProviderConfiguration{}
+	if err := json.Unmarshal(deployItem.Spec.Configuration.Raw, config); err != nil {
+		return "", err
+	}
+	if config.Name == obj.HelmRelease && config.Namespace == obj.HelmReleaseNamespace {
+		return fmt.Sprintf("helm release %s", objectKey(config.Namespace, config.Name)), nil
+	}
+	return "", nil
+}
+
+// ownerChain returns the execution of the deployItem and the installations up to the root installation. The chain
+// ends early if an object cannot be found.
+func (x *objectIndex) ownerChain(deployItem *lsv1alpha1.DeployItem) []OwnerChainLink {
+	chain := []OwnerChainLink{}
+
+	execName, ok := deployItem.Labels[lsv1alpha1.ExecutionManagedByLabel]
+	if !ok {
+		return chain
+	}
+	exec, ok := x.executions[objectKey(deployItem.Namespace, execName)]
+	if !ok {
+		return chain
+	}
+	chain = append(chain, chainLink(exec))
+
+	var inst *lsv1alpha1.Installation
+	for _, candidate := range x.installationList {
+		ref := candidate.Status.ExecutionReference
+		if ref != nil && ref.Name == exec.Name && ref.Namespace == exec.Namespace {
+			inst = candidate
+			break
+		}
+	}
+
+	for inst != nil {
+		chain = append(chain, chainLink(inst))
+		if installations.IsRootInstallation(inst) {
+			break
+		}
+		inst = x.installations[objectKey(inst.Namespace, installations.GetParentInstallationName(inst))]
+	}
+	return chain
+}
+
+func chainLink(obj client.Object) OwnerChainLink {
+	return OwnerChainLink{
+		Kind:      KindOf(obj),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Phase:     PhaseOf(obj),
+	}
+}
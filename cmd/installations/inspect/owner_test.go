@@ -0,0 +1,52 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindOwners(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{K8sClient: fakeClient}
+	expectedChain := []OwnerChainLink{
+		{Kind: "DeployItem", Namespace: "inttest", Name: "ingress-hhsjf-deploy-kptjl", Phase: "Succeeded"},
+		{Kind: "Execution", Namespace: "inttest", Name: "ingress-hhsjf", Phase: "Succeeded"},
+		{Kind: "Installation", Namespace: "inttest", Name: "ingress-hhsjf", Phase: "Succeeded"},
+		{Kind: "Installation", Namespace: "inttest", Name: "my-aggregation", Phase: "Succeeded"},
+	}
+
+	t.Run("Managed resource", func(t *testing.T) {
+		owners, err := collector.FindOwners(context.TODO(), "*", OwnedObject{Kind: "service", Name: "test-ingress-nginx-controller", Namespace: "inttest2"})
+		assert.NoError(t, err)
+		assert.Len(t, owners, 1)
+		assert.Equal(t, "managed resource", owners[0].MatchedBy)
+		assert.Equal(t, "inttest/qw74tlwijt5otemoh5alx55e2hnvcglt", owners[0].Target)
+		assert.Equal(t, expectedChain, owners[0].Chain)
+	})
+
+	t.Run("Helm release", func(t *testing.T) {
+		obj := OwnedObject{Kind: "Deployment", Name: "test-ingress-nginx-controller", Namespace: "inttest2"}
+		owners, err := collector.FindOwners(context.TODO(), "inttest", obj)
+		assert.NoError(t, err)
+		assert.Empty(t, owners)
+
+		obj.HelmRelease = "test"
+		obj.HelmReleaseNamespace = "inttest2"
+		owners, err = collector.FindOwners(context.TODO(), "inttest", obj)
+		assert.NoError(t, err)
+		assert.Len(t, owners, 1)
+		assert.Equal(t, "helm release inttest2/test", owners[0].MatchedBy)
+		assert.Equal(t, expectedChain, owners[0].Chain)
+	})
+
+	t.Run("Other namespace", func(t *testing.T) {
+		owners, err := collector.FindOwners(context.TODO(), "other", OwnedObject{Kind: "Service", Name: "test-ingress-nginx-controller", Namespace: "inttest2"})
+		assert.NoError(t, err)
+		assert.Empty(t, owners)
+	})
+}
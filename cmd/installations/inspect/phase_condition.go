@@ -0,0 +1,131 @@
+package tree
+
+import (
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PhaseConditionStatus is the result of checking a phase condition for an installation tree.
+type PhaseConditionStatus int
+
+const (
+	// PhaseConditionPending means that the condition is not yet fulfilled, but might be fulfilled later.
+	PhaseConditionPending PhaseConditionStatus = iota
+	// PhaseConditionReached means that the root installation has finished its current job and all objects of the tree
+	// have reached the expected phase.
+	PhaseConditionReached
+	// PhaseConditionFailed means that an object of the current job is in a failed phase, although another phase is expected.
+	PhaseConditionFailed
+)
+
+// CheckPhaseCondition checks whether the root installation of the tree has finished its current job and all
+// installations, executions and deployItems of the tree have reached the given phase in this job. Objects which are
+// outdated, i.e. which have a different job ID than the root installation, never count as having reached the phase.
+// Failed phases are only checked for the root installation, because the other objects of a failed job do not
+// necessarily fail as well.
+func CheckPhaseCondition(installationTree *InstallationTree, phase string) PhaseConditionStatus {
+	if isFailedPhase(phase) {
+		inst := installationTree.Installation
+		if inst.Status.JobIDFinished == inst.Status.JobID && string(inst.Status.InstallationPhase) == phase {
+			return PhaseConditionReached
+		}
+		return PhaseConditionPending
+	}
+
+	check := &outdatedCheck{installationTree.Installation.Status.JobID}
+	reached := true
+	failed := false
+
+	installationTree.walk(func(obj client.Object, objPhase string) {
+		if check.isOutdated(obj) {
+			reached = false
+			return
+		}
+		if objPhase != phase {
+			reached = false
+			if isFailedPhase(objPhase) {
+				failed = true
+			}
+		}
+	})
+
+	switch {
+	case failed:
+		return PhaseConditionFailed
+	case reached && installationTree.Installation.Status.JobIDFinished == installationTree.Installation.Status.JobID:
+		return PhaseConditionReached
+	default:
+		return PhaseConditionPending
+	}
+}
+
+// FilterForPhaseNotReached returns a copy of the tree that only contains the objects which are outdated or not in the
+// given phase, together with their parent elements. It returns nil if all objects have reached the phase.
+func (i *InstallationTree) FilterForPhaseNotReached(phase string) *InstallationTree {
+	check := &outdatedCheck{i.Installation.Status.JobID}
+	return i.DeepCopy().filter(func(obj client.Object, objPhase string) bool {
+		return check.isOutdated(obj) || objPhase != phase
+	})
+}
+
+// ObjectPhase is the phase of an installation, execution or deployItem of an installation tree.
+type ObjectPhase struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Phase     string
+	// LastError is the message of the last error of the object. It is only set by ObjectPhases.
+	LastError string
+}
+
+// ObjectPhases returns the phases and last errors of all installations, executions and deployItems of the tree, e.g.
+// to report the progress of a deletion.
+func (i *InstallationTree) ObjectPhases() []ObjectPhase {
+	phases := []ObjectPhase{}
+	i.walk(func(obj client.Object, phase string) {
+		phases = append(phases, ObjectPhase{
+			Kind:      KindOf(obj),
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Phase:     phase,
+			LastError: errorMessage(lastErrorOf(obj)),
+		})
+	})
+	return phases
+}
+
+// IsSettled returns whether all installations, executions and deployItems of the tree are in a final phase, e.g.
+// after an interrupt.
+func (i *InstallationTree) IsSettled() bool {
+	settled := true
+	i.walk(func(_ client.Object, phase string) {
+		if !lsv1alpha1.InstallationPhase(phase).IsFinal() {
+			settled = false
+		}
+	})
+	return settled
+}
+
+// ChangedToFailed returns the objects of the tree which are in a failed phase, but were not failed in the earlier
+// state of the tree, e.g. before an interrupt. The phase of the result is the earlier phase of the object. Objects
+// which are not contained in the earlier tree are returned with an empty phase.
+func (i *InstallationTree) ChangedToFailed(earlier *InstallationTree) []ObjectPhase {
+	earlierPhases := map[string]string{}
+	earlier.walk(func(obj client.Object, phase string) {
+		earlierPhases[KindOf(obj)+"/"+objectKey(obj.GetNamespace(), obj.GetName())] = phase
+	})
+
+	changed := []ObjectPhase{}
+	i.walk(func(obj client.Object, phase string) {
+		earlierPhase := earlierPhases[KindOf(obj)+"/"+objectKey(obj.GetNamespace(), obj.GetName())]
+		if isFailedPhase(phase) && !isFailedPhase(earlierPhase) {
+			changed = append(changed, ObjectPhase{
+				Kind:      KindOf(obj),
+				Namespace: obj.GetNamespace(),
+				Name:      obj.GetName(),
+				Phase:     earlierPhase,
+			})
+		}
+	})
+	return changed
+}
@@ -0,0 +1,122 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPhaseCondition(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	installationTree := installationTrees[0]
+	succeeded := string(lsv1alpha1.InstallationPhases.Succeeded)
+
+	t.Run("All objects succeeded", func(t *testing.T) {
+		assert.Equal(t, PhaseConditionReached, CheckPhaseCondition(installationTree, succeeded))
+		assert.Nil(t, installationTree.FilterForPhaseNotReached(succeeded))
+	})
+
+	t.Run("Outdated objects have not reached the phase", func(t *testing.T) {
+		outdatedTree := installationTree.DeepCopy()
+		outdatedTree.SubInstallations[0].Execution.DeployItems[0].DeployItem.Status.JobID = "old-job"
+		assert.Equal(t, PhaseConditionPending, CheckPhaseCondition(outdatedTree, succeeded))
+
+		failingBranch := outdatedTree.FilterForPhaseNotReached(succeeded)
+		assert.Len(t, failingBranch.SubInstallations, 1)
+		assert.Equal(t, "ingress-hhsjf", failingBranch.SubInstallations[0].Installation.Name)
+	})
+
+	t.Run("Unfinished root installation", func(t *testing.T) {
+		unfinishedTree := installationTree.DeepCopy()
+		unfinishedTree.Installation.Status.JobID = "new-job"
+		unfinishedTree.Installation.Status.JobIDFinished = "old-job"
+		assert.Equal(t, PhaseConditionPending, CheckPhaseCondition(unfinishedTree, succeeded))
+	})
+
+	t.Run("Failed deployItem", func(t *testing.T) {
+		failedTree := installationTree.DeepCopy()
+		failedTree.SubInstallations[1].Execution.DeployItems[0].DeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Failed
+		assert.Equal(t, PhaseConditionFailed, CheckPhaseCondition(failedTree, succeeded))
+		assert.Equal(t, PhaseConditionPending, CheckPhaseCondition(failedTree, string(lsv1alpha1.InstallationPhases.Failed)))
+	})
+
+	t.Run("Failed root installation with succeeded deployItems", func(t *testing.T) {
+		failed := string(lsv1alpha1.InstallationPhases.Failed)
+		failedTree := installationTree.DeepCopy()
+		failedTree.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Failed
+		failedTree.SubInstallations[1].Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Failed
+		assert.Equal(t, PhaseConditionReached, CheckPhaseCondition(failedTree, failed))
+		assert.Equal(t, PhaseConditionFailed, CheckPhaseCondition(failedTree, succeeded))
+		assert.Equal(t, PhaseConditionPending, CheckPhaseCondition(failedTree, string(lsv1alpha1.InstallationPhases.DeleteFailed)))
+
+		failedTree.Installation.Status.JobID = "new-job"
+		assert.Equal(t, PhaseConditionPending, CheckPhaseCondition(failedTree, failed))
+	})
+}
+
+func TestChangedToFailed(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	assert.True(t, installationTrees[0].IsSettled())
+
+	before := installationTrees[0].DeepCopy()
+	before.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Progressing
+	before.SubInstallations[1].Execution.DeployItems[0].DeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Progressing
+	assert.False(t, before.IsSettled())
+
+	after := before.DeepCopy()
+	after.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Failed
+	after.SubInstallations[1].Execution.DeployItems[0].DeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Failed
+	assert.True(t, after.IsSettled())
+
+	changed := after.ChangedToFailed(before)
+	assert.Equal(t, []ObjectPhase{
+		{Kind: "Installation", Namespace: "inttest", Name: "my-aggregation", Phase: "Progressing"},
+		{Kind: "DeployItem", Namespace: "inttest", Name: "server-gw64l-deploy-7mhc2", Phase: "Progressing"},
+	}, changed)
+	assert.Empty(t, after.ChangedToFailed(after))
+}
+
+func TestObjectPhases(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+
+	installationTree := installationTrees[0].DeepCopy()
+	installationTree.Installation.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Deleting
+	deployItem := installationTree.SubInstallations[1].Execution.DeployItems[0].DeployItem
+	deployItem.Status.Phase = lsv1alpha1.DeployItemPhases.DeleteFailed
+	deployItem.Status.LastError = &lsv1alpha1.Error{Message: "cannot delete namespace"}
+
+	phases := installationTree.ObjectPhases()
+	assert.Len(t, phases, 7)
+	assert.Equal(t, ObjectPhase{Kind: "Installation", Namespace: "inttest", Name: "my-aggregation", Phase: "Deleting"}, phases[0])
+	assert.Contains(t, phases, ObjectPhase{
+		Kind:      "DeployItem",
+		Namespace: "inttest",
+		Name:      "server-gw64l-deploy-7mhc2",
+		Phase:     "DeleteFailed",
+		LastError: "cannot delete namespace",
+	})
+}
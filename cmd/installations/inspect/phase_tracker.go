@@ -0,0 +1,37 @@
+package tree
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PhaseTracker remembers the phases of installations, executions and deployItems of the previous transformation,
+// so that nodes whose phase has changed since then can be highlighted.
+type PhaseTracker struct {
+	previous map[string]string
+	current  map[string]string
+}
+
+func NewPhaseTracker() *PhaseTracker {
+	return &PhaseTracker{}
+}
+
+// nextFrame starts a new transformation. The phases recorded so far become the phases to compare with.
+func (p *PhaseTracker) nextFrame() {
+	p.previous = p.current
+	p.current = map[string]string{}
+}
+
+// phaseChanged records the phase of the given object and returns true if the object was not part of the previous
+// transformation or had a different phase. Nothing is reported as changed in the first transformation.
+func (p *PhaseTracker) phaseChanged(kind string, obj client.Object, phase string) bool {
+	key := fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName())
+	p.current[key] = phase
+
+	if p.previous == nil {
+		return false
+	}
+	previousPhase, ok := p.previous[key]
+	return !ok || previousPhase != phase
+}
@@ -3,6 +3,8 @@ package tree
 import (
 	"fmt"
 	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -14,12 +16,21 @@ const (
 
 const terminalWidth = 120
 
+const (
+	highlightStart = "\033[1;7m"
+	highlightEnd   = "\033[0m"
+)
+
 // PrintableTreeNode contains the structure for a printable tree.
 type PrintableTreeNode struct {
 	Headline    string
 	WideData    string // will be displayed in '-o wide' mode
 	Description string
+	Highlighted bool // the headline is displayed in reverse video, e.g. if the phase has changed
 	Childs      []*PrintableTreeNode
+
+	// Object is the installation, execution or deployItem of the node. It is nil for other nodes, e.g. managed resources.
+	Object client.Object
 }
 
 func (node *PrintableTreeNode) print(output *strings.Builder, preFix string, isLast bool, rootLevel bool) {
@@ -36,7 +47,11 @@ func (node *PrintableTreeNode) print(output *strings.Builder, preFix string, isL
 	}
 
 	if node.Headline != "" {
-		fmt.Fprintf(output, "%s%s%s\n", preFix, itemFormatHeading, node.Headline)
+		headline := node.Headline
+		if node.Highlighted {
+			headline = highlightStart + headline + highlightEnd
+		}
+		fmt.Fprintf(output, "%s%s%s\n", preFix, itemFormatHeading, headline)
 		if node.WideData != "" {
 			wData := node.WideData
 			if node.Description != "" {
@@ -0,0 +1,117 @@
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	helmv1alpha1 "github.com/gardener/landscaper/apis/deployer/helm/v1alpha1"
+	"github.com/gardener/landscaper/apis/deployer/utils/managedresource"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Results of purging a resource.
+const (
+	PurgeDeleted     = "deleted"
+	PurgeAlreadyGone = "already gone"
+	PurgeKept        = "kept"
+	PurgeFailed      = "failed"
+)
+
+// PurgedResource is a resource of a deployItem which PurgeDeployed tried to delete from the target cluster.
+type PurgedResource struct {
+	Reference corev1.ObjectReference `json:"reference"`
+	// Result is one of PurgeDeleted, PurgeAlreadyGone, PurgeKept and PurgeFailed.
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// CanPurge returns whether the resources deployed by the deployItem can be purged, which is the case for helm and
+// manifest deployItems.
+func CanPurge(deployItem *lsv1alpha1.DeployItem) bool {
+	return deployItem.Spec.Type == HelmDeployItemType || deployItem.Spec.Type == ManifestDeployItemType
+}
+
+// PurgeDeployed deletes the resources deployed by a helm or manifest deployItem from its target cluster, i.e. the
+// managed resources of its provider status in reverse order. Like on an uninstall by the deployer, resources with the
+// manage policy keep or ignore are not deleted but reported as kept. For helm deployItems, the secrets in which helm stores the
+// release are deleted afterwards, so that the release is uninstalled. Helm hooks are not executed. The target cluster
+// is accessed with the kubeconfig of the target of the deployItem, unless targetClient is set.
+func (c *Collector) PurgeDeployed(ctx context.Context, deployItem *lsv1alpha1.DeployItem, targetClient TargetClientFunc) ([]*PurgedResource, error) {
+	if !CanPurge(deployItem) {
+		return nil, fmt.Errorf("resources deployed by deployitems of type %s cannot be purged", deployItem.Spec.Type)
+	}
+	if deployItem.Spec.Target == nil {
+		return nil, fmt.Errorf("deployitem %s has no target", deployItem.Name)
+	}
+	if targetClient == nil {
+		targetClient = c.clientForTarget
+	}
+
+	statuses, err := managedResourceStatusesOf(deployItem)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode provider status of deployitem %s: %w", deployItem.Name, err)
+	}
+
+	target := &lsv1alpha1.Target{}
+	if err := c.K8sClient.Get(ctx, client.ObjectKey{Name: deployItem.Spec.Target.Name, Namespace: deployItem.Spec.Target.Namespace}, target); err != nil {
+		return nil, fmt.Errorf("cannot get target of deployitem %s: %w", deployItem.Name, err)
+	}
+	targetCl, err := targetClient(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("cannot access target cluster of deployitem %s: %w", deployItem.Name, err)
+	}
+
+	purged := []*PurgedResource{}
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if policy := statuses[i].Policy; policy == managedresource.KeepPolicy || policy == managedresource.IgnorePolicy {
+			purged = append(purged, &PurgedResource{Reference: statuses[i].reference(), Result: PurgeKept})
+			continue
+		}
+		purged = append(purged, purgeResource(ctx, targetCl, statuses[i].reference()))
+	}
+
+	if deployItem.Spec.Type != HelmDeployItemType || deployItem.Spec.Configuration == nil {
+		return purged, nil
+	}
+	config := &helmv1alpha1.ProviderConfiguration{}
+	if err := json.Unmarshal(deployItem.Spec.Configuration.Raw, config); err != nil {
+		return purged, fmt.Errorf("cannot decode configuration of deployitem %s: %w", deployItem.Name, err)
+	}
+	releaseSecrets := &corev1.SecretList{}
+	if err := targetCl.List(ctx, releaseSecrets, client.InNamespace(config.Namespace), client.MatchingLabels{"owner": "helm", "name": config.Name}); err != nil {
+		return purged, fmt.Errorf("cannot list secrets of helm release %s: %w", objectKey(config.Namespace, config.Name), err)
+	}
+	for _, secret := range releaseSecrets.Items {
+		purged = append(purged, purgeResource(ctx, targetCl, corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Namespace:  secret.Namespace,
+			Name:       secret.Name,
+		}))
+	}
+	return purged, nil
+}
+
+func purgeResource(ctx context.Context, targetClient client.Client, ref corev1.ObjectReference) *PurgedResource {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	obj.SetNamespace(ref.Namespace)
+	obj.SetName(ref.Name)
+
+	purged := &PurgedResource{Reference: ref, Result: PurgeDeleted}
+	if err := targetClient.Delete(ctx, obj, client.PropagationPolicy("Background")); err != nil {
+		if apierrors.IsNotFound(err) {
+			purged.Result = PurgeAlreadyGone
+		} else {
+			purged.Result = PurgeFailed
+			purged.Error = err.Error()
+		}
+	}
+	return purged
+}
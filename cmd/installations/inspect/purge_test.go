@@ -0,0 +1,127 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPurgeDeployed(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{K8sClient: fakeClient}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	ingressDeployItem := installationTrees[0].SubInstallations[0].Execution.DeployItems[0].DeployItem
+
+	_, err = collector.PurgeDeployed(context.TODO(), ingressDeployItem, nil)
+	assert.Error(t, err)
+
+	target := &lsv1alpha1.Target{
+		ObjectMeta: metav1.ObjectMeta{Name: "qw74tlwijt5otemoh5alx55e2hnvcglt", Namespace: "inttest"},
+		Spec:       lsv1alpha1.TargetSpec{Type: "landscaper.gardener.cloud/kubernetes-cluster"},
+	}
+	assert.NoError(t, fakeClient.Create(context.TODO(), target))
+
+	targetClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-ingress-nginx-controller", Namespace: "inttest2"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "sh.helm.release.v1.test.v1", Namespace: "inttest2",
+			Labels: map[string]string{"owner": "helm", "name": "test"}}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "sh.helm.release.v1.other.v1", Namespace: "inttest2",
+			Labels: map[string]string{"owner": "helm", "name": "other"}}},
+	).Build()
+	targetClientFunc := func(ctx context.Context, target *lsv1alpha1.Target) (client.Client, error) {
+		assert.Equal(t, "qw74tlwijt5otemoh5alx55e2hnvcglt", target.Name)
+		return targetClient, nil
+	}
+
+	t.Run("Helm deployItem", func(t *testing.T) {
+		purged, err := collector.PurgeDeployed(context.TODO(), ingressDeployItem, targetClientFunc)
+		assert.NoError(t, err)
+		if !assert.Len(t, purged, 2) {
+			return
+		}
+		assert.Equal(t, "Service", purged[0].Reference.Kind)
+		assert.Equal(t, PurgeDeleted, purged[0].Result)
+		assert.Equal(t, "sh.helm.release.v1.test.v1", purged[1].Reference.Name)
+		assert.Equal(t, PurgeDeleted, purged[1].Result)
+
+		err = targetClient.Get(context.TODO(), client.ObjectKey{Name: "sh.helm.release.v1.other.v1", Namespace: "inttest2"}, &corev1.Secret{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Already gone", func(t *testing.T) {
+		purged, err := collector.PurgeDeployed(context.TODO(), ingressDeployItem, targetClientFunc)
+		assert.NoError(t, err)
+		if !assert.Len(t, purged, 1) {
+			return
+		}
+		assert.Equal(t, PurgeAlreadyGone, purged[0].Result)
+	})
+
+	t.Run("Resources with keep and ignore policy", func(t *testing.T) {
+		assert.NoError(t, targetClient.Create(context.TODO(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "managed", Namespace: "inttest2"}}))
+		assert.NoError(t, targetClient.Create(context.TODO(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "kept", Namespace: "inttest2"}}))
+
+		deployItem := ingressDeployItem.DeepCopy()
+		deployItem.Spec.Type = ManifestDeployItemType
+		deployItem.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(`{"managedResources": [
+			{"policy": "manage", "resource": {"apiVersion": "v1", "kind": "ConfigMap", "namespace": "inttest2", "name": "managed"}},
+			{"policy": "keep", "resource": {"apiVersion": "v1", "kind": "ConfigMap", "namespace": "inttest2", "name": "kept"}},
+			{"policy": "ignore", "resource": {"apiVersion": "v1", "kind": "ConfigMap", "namespace": "inttest2", "name": "ignored"}}
+		]}`)}
+
+		purged, err := collector.PurgeDeployed(context.TODO(), deployItem, targetClientFunc)
+		assert.NoError(t, err)
+		if !assert.Len(t, purged, 3) {
+			return
+		}
+		assert.Equal(t, PurgeKept, purged[0].Result)
+		assert.Equal(t, PurgeKept, purged[1].Result)
+		assert.Equal(t, "managed", purged[2].Reference.Name)
+		assert.Equal(t, PurgeDeleted, purged[2].Result)
+
+		err = targetClient.Get(context.TODO(), client.ObjectKey{Name: "kept", Namespace: "inttest2"}, &corev1.ConfigMap{})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unsupported type", func(t *testing.T) {
+		deployItem := ingressDeployItem.DeepCopy()
+		deployItem.Spec.Type = ContainerDeployItemType
+		assert.False(t, CanPurge(deployItem))
+		_, err := collector.PurgeDeployed(context.TODO(), deployItem, targetClientFunc)
+		assert.Error(t, err)
+	})
+}
+
+func TestPurgeDeployedWithTargetSecret(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{K8sClient: fakeClient}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	ingressDeployItem := installationTrees[0].SubInstallations[0].Execution.DeployItems[0].DeployItem
+
+	target, secret := newTestClusterTarget(t, ingressDeployItem.Spec.Target.Name, "target-config")
+	assert.NoError(t, fakeClient.Create(context.TODO(), secret))
+	assert.NoError(t, fakeClient.Create(context.TODO(), target))
+
+	// the target cluster is not reachable, but the client for it is built from the target configuration in the secret
+	purged, err := collector.PurgeDeployed(context.TODO(), ingressDeployItem, nil)
+	assert.ErrorContains(t, err, "127.0.0.1:1")
+	if !assert.NotEmpty(t, purged) {
+		return
+	}
+	assert.Equal(t, PurgeFailed, purged[0].Result)
+	assert.Contains(t, purged[0].Error, "127.0.0.1:1")
+}
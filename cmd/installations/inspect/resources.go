@@ -0,0 +1,292 @@
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/apis/core/v1alpha1/targettypes"
+	"github.com/gardener/landscaper/apis/deployer/utils/managedresource"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Readiness values of managed resources.
+const (
+	ResourceReady    = "Ready"
+	ResourceNotReady = "NotReady"
+	ResourceMissing  = "Missing"
+	ResourceUnknown  = "Unknown"
+)
+
+// ManagedResource is a Kubernetes object which has been deployed by a helm or manifest deployItem, together with the
+// readiness of the live object in the target cluster.
+type ManagedResource struct {
+	Reference corev1.ObjectReference `json:"reference"`
+	// Readiness is one of Ready, NotReady, Missing and Unknown.
+	Readiness string `json:"readiness"`
+	// Status describes the live object, e.g. "2/3 replicas ready" for deployments.
+	Status string `json:"status,omitempty"`
+}
+
+// TargetClientFunc returns a client for the cluster of a target.
+type TargetClientFunc func(ctx context.Context, target *lsv1alpha1.Target) (client.Client, error)
+
+// managedResourcesProviderStatus contains the fields which the provider status of the helm and the manifest deployer
+// have in common.
+type managedResourcesProviderStatus struct {
+	ManagedResources []managedResourceStatus `json:"managedResources,omitempty"`
+}
+
+// managedResourceStatus is an entry of the managed resources in the provider status. Current deployers store the
+// object reference in the resource field (see managedresource.ManagedResourceStatus), older versions of the helm
+// deployer stored it inline.
+type managedResourceStatus struct {
+	Resource *corev1.ObjectReference `json:"resource,omitempty"`
+	// Policy is the manage policy of the manifest deployer, e.g. keep or ignore.
+	Policy managedresource.ManifestPolicy `json:"policy,omitempty"`
+	corev1.ObjectReference
+}
+
+// reference returns the object reference of the managed resource.
+func (s managedResourceStatus) reference() corev1.ObjectReference {
+	if s.Resource != nil {
+		return *s.Resource
+	}
+	return s.ObjectReference
+}
+
+// CollectManagedResources adds the managed resources of all helm and manifest deployItems of the trees to their
+// leaves. The live objects are read from the target clusters, which are accessed with the kubeconfigs of the targets of
+// the deployItems, unless targetClient is set. If a target cluster cannot be accessed, the readiness of its resources
+// is Unknown.
+func (c *Collector) CollectManagedResources(ctx context.Context, installationTrees []*InstallationTree, targetClient TargetClientFunc) error {
+	if targetClient == nil {
+		targetClient = c.clientForTarget
+	}
+	clients := map[string]client.Client{}
+	clientErrors := map[string]error{}
+
+	for _, installationTree := range installationTrees {
+		for _, leaf := range installationTree.deployItemLeaves() {
+			deployItem := leaf.DeployItem
+			refs, err := ManagedResourcesOf(deployItem)
+			if err != nil {
+				return fmt.Errorf("cannot decode provider status of deployitem %s: %w", deployItem.Name, err)
+			}
+			if len(refs) == 0 {
+				continue
+			}
+
+			var targetCl client.Client
+			var targetErr error
+			if deployItem.Spec.Target == nil {
+				targetErr = fmt.Errorf("deployitem has no target")
+			} else {
+				key := objectKey(deployItem.Spec.Target.Namespace, deployItem.Spec.Target.Name)
+				targetCl, targetErr = clients[key], clientErrors[key]
+				if targetCl == nil && targetErr == nil {
+					target := &lsv1alpha1.Target{}
+					targetErr = c.K8sClient.Get(ctx, client.ObjectKey{Name: deployItem.Spec.Target.Name, Namespace: deployItem.Spec.Target.Namespace}, target)
+					if targetErr == nil {
+						targetCl, targetErr = targetClient(ctx, target)
+					}
+					clients[key], clientErrors[key] = targetCl, targetErr
+				}
+			}
+
+			leaf.Resources = nil
+			for _, ref := range refs {
+				resource := &ManagedResource{Reference: ref}
+				if targetErr != nil {
+					resource.Readiness = ResourceUnknown
+					resource.Status = fmt.Sprintf("cannot access target cluster: %s", targetErr.Error())
+				} else {
+					resource.Readiness, resource.Status = checkReadiness(ctx, targetCl, ref)
+				}
+				leaf.Resources = append(leaf.Resources, resource)
+			}
+		}
+	}
+	return nil
+}
+
+func (i *InstallationTree) deployItemLeaves() []*DeployItemLeaf {
+	leaves := []*DeployItemLeaf{}
+	for _, subInstallation := range i.SubInstallations {
+		leaves = append(leaves, subInstallation.deployItemLeaves()...)
+	}
+	if i.Execution != nil {
+		leaves = append(leaves, i.Execution.DeployItems...)
+	}
+	return leaves
+}
+
+// ManagedResourcesOf returns the managed resources from the provider status of helm and manifest deployItems.
+func ManagedResourcesOf(deployItem *lsv1alpha1.DeployItem) ([]corev1.ObjectReference, error) {
+	statuses, err := managedResourceStatusesOf(deployItem)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]corev1.ObjectReference, 0, len(statuses))
+	for _, status := range statuses {
+		refs = append(refs, status.reference())
+	}
+	return refs, nil
+}
+
+func managedResourceStatusesOf(deployItem *lsv1alpha1.DeployItem) ([]managedResourceStatus, error) {
+	if deployItem.Spec.Type != HelmDeployItemType && deployItem.Spec.Type != ManifestDeployItemType {
+		return nil, nil
+	}
+	if deployItem.Status.ProviderStatus == nil || len(deployItem.Status.ProviderStatus.Raw) == 0 {
+		return nil, nil
+	}
+
+	providerStatus := &managedResourcesProviderStatus{}
+	if err := json.Unmarshal(deployItem.Status.ProviderStatus.Raw, providerStatus); err != nil {
+		return nil, err
+	}
+	return providerStatus.ManagedResources, nil
+}
+
+// clientForTarget builds a client from the kubeconfig of a kubernetes-cluster target. The target configuration, which
+// contains the kubeconfig, is either part of the target or stored in the secret referenced by the target.
+func (c *Collector) clientForTarget(ctx context.Context, target *lsv1alpha1.Target) (client.Client, error) {
+	if target.Spec.Type != targettypes.KubernetesClusterTargetType {
+		return nil, fmt.Errorf("target %s has unsupported type %s", target.Name, target.Spec.Type)
+	}
+
+	var rawConfig []byte
+	if target.Spec.SecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := c.K8sClient.Get(ctx, client.ObjectKey{Name: target.Spec.SecretRef.Name, Namespace: target.Namespace}, secret); err != nil {
+			return nil, fmt.Errorf("cannot get secret of target %s: %w", target.Name, err)
+		}
+		if key := target.Spec.SecretRef.Key; len(key) > 0 {
+			rawConfig = secret.Data[key]
+		} else {
+			// without key, the whole secret data is the target configuration
+			config := map[string]string{}
+			for key, value := range secret.Data {
+				config[key] = string(value)
+			}
+			data, err := json.Marshal(config)
+			if err != nil {
+				return nil, fmt.Errorf("cannot decode secret of target %s: %w", target.Name, err)
+			}
+			rawConfig = data
+		}
+	} else if target.Spec.Configuration != nil {
+		rawConfig = target.Spec.Configuration.RawMessage
+	}
+
+	var kubeconfig []byte
+	if len(rawConfig) > 0 {
+		config := &targettypes.KubernetesClusterTargetConfig{}
+		if err := yaml.Unmarshal(rawConfig, config); err != nil {
+			return nil, fmt.Errorf("cannot decode configuration of target %s: %w", target.Name, err)
+		}
+		if config.Kubeconfig.StrVal != nil {
+			kubeconfig = []byte(*config.Kubeconfig.StrVal)
+		}
+	}
+	if len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("target %s contains no kubeconfig", target.Name)
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build config from kubeconfig of target %s: %w", target.Name, err)
+	}
+	return client.New(restConfig, client.Options{})
+}
+
+// checkReadiness reads the live object and computes its readiness. Deployments, statefulsets, daemonsets, pods and
+// jobs are checked in detail, other objects are ready if they exist.
+func checkReadiness(ctx context.Context, targetClient client.Client, ref corev1.ObjectReference) (string, string) {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+	if err := targetClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ResourceMissing, "not found"
+		}
+		return ResourceUnknown, err.Error()
+	}
+
+	var err error
+	readiness, status := ResourceReady, "exists"
+	switch obj.GroupVersionKind().GroupKind().String() {
+	case "Deployment.apps":
+		deployment := &appsv1.Deployment{}
+		if err = fromUnstructured(obj, deployment); err == nil {
+			desired := replicasOrDefault(deployment.Spec.Replicas)
+			status = fmt.Sprintf("%d/%d replicas ready", deployment.Status.ReadyReplicas, desired)
+			if deployment.Status.ReadyReplicas < desired || deployment.Status.UpdatedReplicas < desired ||
+				deployment.Status.ObservedGeneration < deployment.Generation {
+				readiness = ResourceNotReady
+			}
+		}
+	case "StatefulSet.apps":
+		statefulSet := &appsv1.StatefulSet{}
+		if err = fromUnstructured(obj, statefulSet); err == nil {
+			desired := replicasOrDefault(statefulSet.Spec.Replicas)
+			status = fmt.Sprintf("%d/%d replicas ready", statefulSet.Status.ReadyReplicas, desired)
+			if statefulSet.Status.ReadyReplicas < desired || statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+				readiness = ResourceNotReady
+			}
+		}
+	case "DaemonSet.apps":
+		daemonSet := &appsv1.DaemonSet{}
+		if err = fromUnstructured(obj, daemonSet); err == nil {
+			status = fmt.Sprintf("%d/%d pods ready", daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled)
+			if daemonSet.Status.NumberReady < daemonSet.Status.DesiredNumberScheduled {
+				readiness = ResourceNotReady
+			}
+		}
+	case "Pod":
+		pod := &corev1.Pod{}
+		if err = fromUnstructured(obj, pod); err == nil {
+			status = fmt.Sprintf("phase %s", pod.Status.Phase)
+			if pod.Status.Phase != corev1.PodRunning && pod.Status.Phase != corev1.PodSucceeded {
+				readiness = ResourceNotReady
+			}
+		}
+	case "Job.batch":
+		job := &batchv1.Job{}
+		if err = fromUnstructured(obj, job); err == nil {
+			completions := replicasOrDefault(job.Spec.Completions)
+			status = fmt.Sprintf("%d/%d completions", job.Status.Succeeded, completions)
+			if job.Status.Failed > 0 {
+				status = fmt.Sprintf("%s, %d failed", status, job.Status.Failed)
+			}
+			if job.Status.Succeeded < completions {
+				readiness = ResourceNotReady
+			}
+		}
+	}
+	if err != nil {
+		return ResourceUnknown, fmt.Sprintf("cannot decode %s: %s", ref.Kind, err.Error())
+	}
+	return readiness, status
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, into interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, into)
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
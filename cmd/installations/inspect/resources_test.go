@@ -0,0 +1,164 @@
+package tree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/apis/core/v1alpha1/targettypes"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+func TestCollectManagedResources(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{K8sClient: fakeClient}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+
+	// the ingress deployItem has a helm provider status with inline references, the server deployItem gets a
+	// manifest provider status with nested references
+	ingressLeaf := installationTrees[0].SubInstallations[0].Execution.DeployItems[0]
+	serverLeaf := installationTrees[0].SubInstallations[1].Execution.DeployItems[0]
+	serverLeaf.DeployItem.Status.ProviderStatus = &runtime.RawExtension{Raw: []byte(`{
+		"apiVersion": "manifest.deployer.landscaper.gardener.cloud/v1alpha2",
+		"kind": "ProviderStatus",
+		"managedResources": [
+			{"policy": "manage", "resource": {"apiVersion": "apps/v1", "kind": "Deployment", "name": "echo-server", "namespace": "inttest2"}},
+			{"policy": "manage", "resource": {"apiVersion": "batch/v1", "kind": "Job", "name": "migrate", "namespace": "inttest2"}},
+			{"policy": "manage", "resource": {"apiVersion": "v1", "kind": "Pod", "name": "debug", "namespace": "inttest2"}}
+		]}`)}
+
+	targetClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "test-ingress-nginx-controller", Namespace: "inttest2"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "echo-server", Namespace: "inttest2"},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](3)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 2, UpdatedReplicas: 3},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "inttest2"},
+			Status:     batchv1.JobStatus{Succeeded: 1, Failed: 2},
+		},
+	).Build()
+
+	var requestedTargets []string
+	targetClientFunc := func(ctx context.Context, target *lsv1alpha1.Target) (client.Client, error) {
+		requestedTargets = append(requestedTargets, target.Name)
+		return targetClient, nil
+	}
+
+	// without the target, the readiness is unknown
+	err = collector.CollectManagedResources(context.TODO(), installationTrees, targetClientFunc)
+	assert.NoError(t, err)
+	assert.Empty(t, requestedTargets)
+	assert.Len(t, ingressLeaf.Resources, 1)
+	assert.Equal(t, ResourceUnknown, ingressLeaf.Resources[0].Readiness)
+
+	target := &lsv1alpha1.Target{
+		ObjectMeta: metav1.ObjectMeta{Name: "qw74tlwijt5otemoh5alx55e2hnvcglt", Namespace: "inttest"},
+		Spec:       lsv1alpha1.TargetSpec{Type: "landscaper.gardener.cloud/kubernetes-cluster"},
+	}
+	assert.NoError(t, fakeClient.Create(context.TODO(), target))
+
+	err = collector.CollectManagedResources(context.TODO(), installationTrees, targetClientFunc)
+	assert.NoError(t, err)
+	// the client is built once per target
+	assert.Equal(t, []string{"qw74tlwijt5otemoh5alx55e2hnvcglt"}, requestedTargets)
+
+	format := func(resources []*ManagedResource) []string {
+		formatted := []string{}
+		for _, resource := range resources {
+			formatted = append(formatted, fmt.Sprintf("%s %s: %s (%s)", resource.Reference.Kind, resource.Reference.Name, resource.Readiness, resource.Status))
+		}
+		return formatted
+	}
+	assert.Equal(t, []string{"Service test-ingress-nginx-controller: Ready (exists)"}, format(ingressLeaf.Resources))
+	assert.Equal(t, []string{
+		"Deployment echo-server: NotReady (2/3 replicas ready)",
+		"Job migrate: Ready (1/1 completions, 2 failed)",
+		"Pod debug: Missing (not found)",
+	}, format(serverLeaf.Resources))
+
+	transformer := NewTransformer(false, false, false, false)
+	printableTrees, err := transformer.TransformToPrintableTrees(installationTrees)
+	assert.NoError(t, err)
+	printed := PrintTrees(printableTrees)
+	output := printed.String()
+	assert.Contains(t, output, "[🏗️ NotReady] Deployment inttest2/echo-server")
+	assert.Contains(t, output, "2/3 replicas ready")
+	assert.Contains(t, output, "[❌ Missing] Pod inttest2/debug")
+}
+
+const testTargetKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:1
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: test
+`
+
+// newTestClusterTarget returns a kubernetes-cluster target with a kubeconfig for an unreachable cluster, built like
+// the targets of the targets create command.
+func newTestClusterTarget(t *testing.T, name, secretName string) (*lsv1alpha1.Target, *corev1.Secret) {
+	content, err := json.Marshal(targettypes.KubernetesClusterTargetConfig{
+		Kubeconfig: targettypes.ValueRef{StrVal: ptr.To(testTargetKubeconfig)},
+	})
+	assert.NoError(t, err)
+	return util.BuildTargetWithContent(name, "inttest", targettypes.KubernetesClusterTargetType, content, secretName)
+}
+
+func TestClientForTarget(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+	collector := Collector{K8sClient: fakeClient}
+
+	t.Run("Target with secret", func(t *testing.T) {
+		target, secret := newTestClusterTarget(t, "secret-target", "secret-target-config")
+		assert.NoError(t, fakeClient.Create(context.TODO(), secret))
+		targetClient, err := collector.clientForTarget(context.TODO(), target)
+		assert.NoError(t, err)
+		assert.NotNil(t, targetClient)
+	})
+
+	t.Run("Target with secret without key", func(t *testing.T) {
+		target, secret := newTestClusterTarget(t, "keyless-target", "keyless-target-config")
+		secret.Data = map[string][]byte{"kubeconfig": []byte(testTargetKubeconfig)}
+		target.Spec.SecretRef.Key = ""
+		assert.NoError(t, fakeClient.Create(context.TODO(), secret))
+		targetClient, err := collector.clientForTarget(context.TODO(), target)
+		assert.NoError(t, err)
+		assert.NotNil(t, targetClient)
+	})
+
+	t.Run("Target with inline configuration", func(t *testing.T) {
+		target, _ := newTestClusterTarget(t, "inline-target", "")
+		targetClient, err := collector.clientForTarget(context.TODO(), target)
+		assert.NoError(t, err)
+		assert.NotNil(t, targetClient)
+	})
+}
@@ -0,0 +1,159 @@
+package tree
+
+import (
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SummarySchemaVersion is the version of the condensed json schema of the summary output. Fields may be added to the
+// schema, but existing fields are not renamed or removed without increasing the version.
+const SummarySchemaVersion = "v1"
+
+// Summary is the condensed representation of installation trees for scripting. It is printed with '-o summary-json'
+// and is the input for the table, custom-columns, jsonpath and go-template outputs.
+type Summary struct {
+	SchemaVersion string                 `json:"schemaVersion"`
+	Installations []*InstallationSummary `json:"installations"`
+}
+
+// InstallationSummary is the condensed representation of an installation and its subtree.
+type InstallationSummary struct {
+	Namespace         string      `json:"namespace"`
+	Name              string      `json:"name"`
+	Phase             string      `json:"phase"`
+	Outdated          bool        `json:"outdated"`
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+	// Component is the component name or "inline" for inline component descriptors.
+	Component string `json:"component"`
+	Version   string `json:"version,omitempty"`
+	// Blueprint is the resource name of the blueprint or "inline" for inline blueprints.
+	Blueprint        string                 `json:"blueprint"`
+	JobID            string                 `json:"jobID,omitempty"`
+	JobIDFinished    string                 `json:"jobIDFinished,omitempty"`
+	LastError        string                 `json:"lastError,omitempty"`
+	Execution        *ExecutionSummary      `json:"execution,omitempty"`
+	SubInstallations []*InstallationSummary `json:"subInstallations,omitempty"`
+}
+
+// ExecutionSummary is the condensed representation of an execution and its deployItems.
+type ExecutionSummary struct {
+	Name          string               `json:"name"`
+	Phase         string               `json:"phase"`
+	Outdated      bool                 `json:"outdated"`
+	JobID         string               `json:"jobID,omitempty"`
+	JobIDFinished string               `json:"jobIDFinished,omitempty"`
+	LastError     string               `json:"lastError,omitempty"`
+	DeployItems   []*DeployItemSummary `json:"deployItems,omitempty"`
+}
+
+// DeployItemSummary is the condensed representation of a deployItem.
+type DeployItemSummary struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Phase         string `json:"phase"`
+	Outdated      bool   `json:"outdated"`
+	JobID         string `json:"jobID,omitempty"`
+	JobIDFinished string `json:"jobIDFinished,omitempty"`
+	LastError     string `json:"lastError,omitempty"`
+	// Resources are only set with --show-resources.
+	Resources []*ManagedResource `json:"resources,omitempty"`
+}
+
+// TransformToSummary transforms a []*InstallationTree to a Summary, applying the same filters as
+// TransformToPrintableTrees.
+func (t *Transformer) TransformToSummary(installationTrees []*InstallationTree) *Summary {
+	summary := &Summary{
+		SchemaVersion: SummarySchemaVersion,
+		Installations: []*InstallationSummary{},
+	}
+
+	for _, installationTree := range installationTrees {
+		if t.showOnlyFailed {
+			installationTree = installationTree.filterForFailedInstallation()
+		}
+		if installationTree == nil {
+			continue
+		}
+
+		check := &outdatedCheck{installationTree.Installation.Status.JobID}
+		summary.Installations = append(summary.Installations, summarizeInstallation(installationTree, check))
+	}
+	return summary
+}
+
+// Flatten returns all installations of the summary including the subinstallations in tree order.
+func (s *Summary) Flatten() []*InstallationSummary {
+	installations := []*InstallationSummary{}
+	var add func(inst *InstallationSummary)
+	add = func(inst *InstallationSummary) {
+		installations = append(installations, inst)
+		for _, subInst := range inst.SubInstallations {
+			add(subInst)
+		}
+	}
+	for _, inst := range s.Installations {
+		add(inst)
+	}
+	return installations
+}
+
+func summarizeInstallation(installationTree *InstallationTree, check *outdatedCheck) *InstallationSummary {
+	inst := installationTree.Installation
+	summary := &InstallationSummary{
+		Namespace:         inst.Namespace,
+		Name:              inst.Name,
+		Phase:             string(inst.Status.InstallationPhase),
+		Outdated:          check.isInstallationOutdated(inst),
+		CreationTimestamp: inst.CreationTimestamp,
+		Component:         "inline",
+		Blueprint:         "inline",
+		JobID:             inst.Status.JobID,
+		JobIDFinished:     inst.Status.JobIDFinished,
+		LastError:         errorMessage(inst.Status.LastError),
+	}
+	if inst.Spec.ComponentDescriptor != nil && inst.Spec.ComponentDescriptor.Reference != nil {
+		summary.Component = inst.Spec.ComponentDescriptor.Reference.ComponentName
+		summary.Version = inst.Spec.ComponentDescriptor.Reference.Version
+	}
+	if inst.Spec.Blueprint.Reference != nil {
+		summary.Blueprint = inst.Spec.Blueprint.Reference.ResourceName
+	}
+
+	for _, subInst := range installationTree.SubInstallations {
+		summary.SubInstallations = append(summary.SubInstallations, summarizeInstallation(subInst, check))
+	}
+
+	if installationTree.Execution != nil {
+		exec := installationTree.Execution.Execution
+		summary.Execution = &ExecutionSummary{
+			Name:          exec.Name,
+			Phase:         string(exec.Status.ExecutionPhase),
+			Outdated:      check.isExecutionOutdated(exec),
+			JobID:         exec.Status.JobID,
+			JobIDFinished: exec.Status.JobIDFinished,
+			LastError:     errorMessage(exec.Status.LastError),
+		}
+		for _, depItem := range installationTree.Execution.DeployItems {
+			di := depItem.DeployItem
+			summary.Execution.DeployItems = append(summary.Execution.DeployItems, &DeployItemSummary{
+				Name:          di.Name,
+				Type:          string(di.Spec.Type),
+				Phase:         string(di.Status.Phase),
+				Outdated:      check.isDeployItemOutdated(di),
+				JobID:         di.Status.JobID,
+				JobIDFinished: di.Status.JobIDFinished,
+				LastError:     errorMessage(di.Status.LastError),
+				Resources:     depItem.Resources,
+			})
+		}
+	}
+
+	return summary
+}
+
+func errorMessage(lsError *lsv1alpha1.Error) string {
+	if lsError == nil {
+		return ""
+	}
+	return lsError.Message
+}
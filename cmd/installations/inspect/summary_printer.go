@@ -0,0 +1,239 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Output formats which are computed from the Summary.
+const (
+	OutputTable         = "table"
+	OutputSummaryJSON   = "summary-json"
+	OutputCustomColumns = "custom-columns"
+	OutputJSONPath      = "jsonpath"
+	OutputGoTemplate    = "go-template"
+)
+
+// SummaryPrinter prints a Summary in one of the output formats table, summary-json, custom-columns=<spec>,
+// jsonpath=<template> and go-template=<template>. Tables and custom columns contain one row per installation,
+// including the subinstallations. Json paths and go templates are evaluated on the summary-json document.
+type SummaryPrinter struct {
+	format   string
+	columns  []customColumn
+	jsonPath *jsonpath.JSONPath
+	template *template.Template
+}
+
+type customColumn struct {
+	header   string
+	jsonPath *jsonpath.JSONPath
+}
+
+// IsSummaryOutput returns whether the value of the output flag is handled by the SummaryPrinter.
+func IsSummaryOutput(output string) bool {
+	format, _, _ := strings.Cut(output, "=")
+	switch format {
+	case OutputTable, OutputSummaryJSON, OutputCustomColumns, OutputJSONPath, OutputGoTemplate:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewSummaryPrinter parses the value of the output flag.
+func NewSummaryPrinter(output string) (*SummaryPrinter, error) {
+	format, spec, hasSpec := strings.Cut(output, "=")
+	p := &SummaryPrinter{format: format}
+
+	switch format {
+	case OutputTable, OutputSummaryJSON:
+		if hasSpec {
+			return nil, fmt.Errorf("output format %s does not accept a specification", format)
+		}
+
+	case OutputCustomColumns:
+		if len(spec) == 0 {
+			return nil, fmt.Errorf("custom-columns format specified but no custom columns given, e.g. -o custom-columns=NAME:.name,PHASE:.phase")
+		}
+		for _, column := range strings.Split(spec, ",") {
+			header, path, ok := strings.Cut(column, ":")
+			if !ok {
+				return nil, fmt.Errorf("expected <header>:<json-path-expr> in custom column %q", column)
+			}
+			parsedPath, err := parseJSONPath(header, path)
+			if err != nil {
+				return nil, err
+			}
+			p.columns = append(p.columns, customColumn{header: header, jsonPath: parsedPath})
+		}
+
+	case OutputJSONPath:
+		if len(spec) == 0 {
+			return nil, fmt.Errorf("jsonpath format specified but no jsonpath template given")
+		}
+		parsedPath, err := parseJSONPath("output", spec)
+		if err != nil {
+			return nil, err
+		}
+		p.jsonPath = parsedPath
+
+	case OutputGoTemplate:
+		if len(spec) == 0 {
+			return nil, fmt.Errorf("go-template format specified but no go-template given")
+		}
+		parsedTemplate, err := template.New("output").Parse(spec)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing go-template %q: %w", spec, err)
+		}
+		p.template = parsedTemplate
+
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+
+	return p, nil
+}
+
+// parseJSONPath parses a json path. Like kubectl, it accepts expressions without the surrounding braces.
+func parseJSONPath(name, path string) (*jsonpath.JSONPath, error) {
+	if !strings.Contains(path, "{") {
+		path = fmt.Sprintf("{%s}", path)
+	}
+	parsedPath := jsonpath.New(name).AllowMissingKeys(true)
+	if err := parsedPath.Parse(path); err != nil {
+		return nil, fmt.Errorf("error parsing jsonpath %q: %w", path, err)
+	}
+	return parsedPath, nil
+}
+
+// Print writes the summary in the configured format.
+func (p *SummaryPrinter) Print(w io.Writer, summary *Summary) error {
+	switch p.format {
+	case OutputTable:
+		return p.printTable(w, summary)
+
+	case OutputSummaryJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+
+	case OutputCustomColumns:
+		return p.printCustomColumns(w, summary)
+
+	case OutputJSONPath:
+		data, err := toGenericJSON(summary)
+		if err != nil {
+			return err
+		}
+		if err := p.jsonPath.Execute(w, data); err != nil {
+			return fmt.Errorf("error executing jsonpath: %w", err)
+		}
+		_, err = fmt.Fprintln(w)
+		return err
+
+	case OutputGoTemplate:
+		data, err := toGenericJSON(summary)
+		if err != nil {
+			return err
+		}
+		if err := p.template.Execute(w, data); err != nil {
+			return fmt.Errorf("error executing go-template: %w", err)
+		}
+		_, err = fmt.Fprintln(w)
+		return err
+	}
+
+	return nil
+}
+
+func (p *SummaryPrinter) printTable(w io.Writer, summary *Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tPHASE\tOUTDATED\tAGE\tCOMPONENT\tVERSION\tBLUEPRINT\tLAST ERROR")
+	for _, inst := range summary.Flatten() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%s\t%s\t%s\t%s\t%s\n",
+			inst.Namespace,
+			inst.Name,
+			valueOrNone(inst.Phase),
+			inst.Outdated,
+			formatAge(inst.CreationTimestamp.Time),
+			inst.Component,
+			valueOrNone(inst.Version),
+			inst.Blueprint,
+			valueOrNone(singleLine(inst.LastError)))
+	}
+	return tw.Flush()
+}
+
+func (p *SummaryPrinter) printCustomColumns(w io.Writer, summary *Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	headers := make([]string, 0, len(p.columns))
+	for _, column := range p.columns {
+		headers = append(headers, column.header)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, inst := range summary.Flatten() {
+		data, err := toGenericJSON(inst)
+		if err != nil {
+			return err
+		}
+
+		values := make([]string, 0, len(p.columns))
+		for _, column := range p.columns {
+			results, err := column.jsonPath.FindResults(data)
+			if err != nil {
+				return fmt.Errorf("error evaluating column %s for installation %s: %w", column.header, inst.Name, err)
+			}
+			parts := []string{}
+			for _, result := range results {
+				for _, value := range result {
+					parts = append(parts, fmt.Sprint(value.Interface()))
+				}
+			}
+			values = append(values, valueOrNone(singleLine(strings.Join(parts, ","))))
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// toGenericJSON converts the value to maps and slices, so that json paths and templates use the json field names.
+func toGenericJSON(value interface{}) (interface{}, error) {
+	marshaled, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed marshaling summary: %w", err)
+	}
+	var data interface{}
+	if err := json.Unmarshal(marshaled, &data); err != nil {
+		return nil, fmt.Errorf("failed unmarshaling summary: %w", err)
+	}
+	return data, nil
+}
+
+func formatAge(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	return duration.HumanDuration(time.Since(t))
+}
+
+func valueOrNone(value string) string {
+	if len(value) == 0 {
+		return "<none>"
+	}
+	return value
+}
+
+func singleLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
@@ -0,0 +1,71 @@
+package tree
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummary(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	installationTrees[0].SubInstallations[1].Execution.DeployItems[0].DeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Failed
+
+	summary := NewTransformer(false, false, false, false).TransformToSummary(installationTrees)
+
+	t.Run("Summary of installation tree", func(t *testing.T) {
+		assert.Equal(t, SummarySchemaVersion, summary.SchemaVersion)
+		assert.Len(t, summary.Installations, 1)
+		flattened := summary.Flatten()
+		assert.Len(t, flattened, 3)
+		assert.Equal(t, "my-aggregation", flattened[0].Name)
+		assert.Equal(t, "github.com/gardener/landscaper/simple-aggregated", flattened[0].Component)
+		assert.Equal(t, "v0.2.0", flattened[0].Version)
+		assert.Equal(t, "simple-aggregated", flattened[0].Blueprint)
+		assert.Equal(t, "server-gw64l", flattened[2].Name)
+		assert.Equal(t, string(lsv1alpha1.DeployItemPhases.Failed), flattened[2].Execution.DeployItems[0].Phase)
+	})
+
+	t.Run("Custom columns", func(t *testing.T) {
+		printer, err := NewSummaryPrinter("custom-columns=NAME:.name,ITEMS:.execution.deployItems[*].phase")
+		assert.NoError(t, err)
+		out := &bytes.Buffer{}
+		assert.NoError(t, printer.Print(out, summary))
+		assert.Equal(t, "NAME             ITEMS\n"+
+			"my-aggregation   <none>\n"+
+			"ingress-hhsjf    Succeeded\n"+
+			"server-gw64l     Failed\n", out.String())
+	})
+
+	t.Run("Json path and go template", func(t *testing.T) {
+		printer, err := NewSummaryPrinter("jsonpath={.installations[*].subInstallations[*].name}")
+		assert.NoError(t, err)
+		out := &bytes.Buffer{}
+		assert.NoError(t, printer.Print(out, summary))
+		assert.Equal(t, "ingress-hhsjf server-gw64l\n", out.String())
+
+		printer, err = NewSummaryPrinter("go-template={{range .installations}}{{.name}}:{{.phase}}{{end}}")
+		assert.NoError(t, err)
+		out.Reset()
+		assert.NoError(t, printer.Print(out, summary))
+		assert.Equal(t, "my-aggregation:Succeeded\n", out.String())
+	})
+
+	t.Run("Invalid output formats", func(t *testing.T) {
+		assert.False(t, IsSummaryOutput("tree"))
+		_, err := NewSummaryPrinter("custom-columns=NAME")
+		assert.Error(t, err)
+		_, err = NewSummaryPrinter("table=NAME")
+		assert.Error(t, err)
+	})
+}
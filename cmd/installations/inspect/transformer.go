@@ -1,13 +1,13 @@
 package tree
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
-	containerv1alpha1 "github.com/gardener/landscaper/apis/deployer/container/v1alpha1"
-	helmv1alpha1 "github.com/gardener/landscaper/apis/deployer/helm/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 )
 
@@ -17,6 +17,10 @@ type Transformer struct {
 	showOnlyFailed bool
 	showNamespaces bool
 	wideMode       bool
+
+	phaseTracker *PhaseTracker
+	durations    bool
+	dataFlow     bool
 }
 
 func NewTransformer(detailedMode, showOnlyFailed, showNamespaces, wideMode bool) *Transformer {
@@ -28,13 +32,33 @@ func NewTransformer(detailedMode, showOnlyFailed, showNamespaces, wideMode bool)
 	}
 }
 
+// WithPhaseTracker lets the transformer highlight all nodes whose phase has changed since the previous transformation.
+func (t *Transformer) WithPhaseTracker(phaseTracker *PhaseTracker) *Transformer {
+	t.phaseTracker = phaseTracker
+	return t
+}
+
+// WithDurations lets the transformer annotate each node with the duration of its last job.
+func (t *Transformer) WithDurations(durations bool) *Transformer {
+	t.durations = durations
+	return t
+}
+
 // TransformToPrintableTrees transform a []*InstallationTree to []PrintableTreeNodes for the Printer.
 func (t *Transformer) TransformToPrintableTrees(installationTrees []*InstallationTree) ([]PrintableTreeNode, error) {
 	var printableTrees []PrintableTreeNode
 
+	if t.phaseTracker != nil {
+		t.phaseTracker.nextFrame()
+	}
+
 	for _, installationTree := range installationTrees {
 		if t.showOnlyFailed {
 			installationTree = installationTree.filterForFailedInstallation()
+			if installationTree == nil {
+				// the tree contains no failed object
+				continue
+			}
 		}
 
 		check := &outdatedCheck{installationTree.Installation.Status.JobID}
@@ -55,17 +79,20 @@ func (t *Transformer) transformInstallation(installationTree *InstallationTree,
 	}
 
 	installationTree.Installation.SetManagedFields(nil)
+	printableNode.Object = installationTree.Installation
 
 	namespaceInfo := ""
 	if t.showNamespaces {
 		namespaceInfo = fmt.Sprintf("%s/", installationTree.Installation.Namespace)
 	}
 
-	printableNode.Headline = fmt.Sprintf("[%s%s] Installation %s%s",
+	printableNode.Headline = fmt.Sprintf("[%s%s] Installation %s%s%s",
 		formatStatus(string(installationTree.Installation.Status.InstallationPhase)),
 		formatOutdated(check.isInstallationOutdated(installationTree.Installation)),
 		namespaceInfo,
-		installationTree.Installation.Name)
+		installationTree.Installation.Name,
+		t.formatDuration(installationTree.Installation))
+	printableNode.Highlighted = t.phaseChanged("Installation", installationTree.Installation, string(installationTree.Installation.Status.InstallationPhase))
 
 	if t.wideMode {
 		wide := strings.Builder{}
@@ -78,7 +105,8 @@ func (t *Transformer) transformInstallation(installationTree *InstallationTree,
 		if installationTree.Installation.Spec.Blueprint.Reference != nil {
 			bp = installationTree.Installation.Spec.Blueprint.Reference.ResourceName
 		}
-		wide.WriteString(fmt.Sprintf("Blueprint: %s", bp))
+		wide.WriteString(fmt.Sprintf("Blueprint: %s\n", bp))
+		wide.WriteString(formatTimes(installationTree.Installation))
 		printableNode.WideData = wide.String()
 	}
 
@@ -122,11 +150,18 @@ func (t *Transformer) transformExecution(executionTree *ExecutionTree, check *ou
 	}
 
 	executionTree.Execution.SetManagedFields(nil)
+	printableNode.Object = executionTree.Execution
 
-	printableNode.Headline = fmt.Sprintf("[%s%s] Execution %s",
+	printableNode.Headline = fmt.Sprintf("[%s%s] Execution %s%s",
 		formatStatus(string(executionTree.Execution.Status.ExecutionPhase)),
 		formatOutdated(check.isExecutionOutdated(executionTree.Execution)),
-		executionTree.Execution.Name)
+		executionTree.Execution.Name,
+		t.formatDuration(executionTree.Execution))
+	printableNode.Highlighted = t.phaseChanged("Execution", executionTree.Execution, string(executionTree.Execution.Status.ExecutionPhase))
+
+	if t.wideMode {
+		printableNode.WideData = formatTimes(executionTree.Execution)
+	}
 
 	if t.detailedMode {
 		marshaledExecution, err := yaml.Marshal(executionTree.Execution)
@@ -158,71 +193,25 @@ func (t *Transformer) transformDeployItem(deployItem *DeployItemLeaf, check *out
 	}
 
 	deployItem.DeployItem.SetManagedFields(nil)
+	printableNode.Object = deployItem.DeployItem
 
-	printableNode.Headline = fmt.Sprintf("[%s%s] DeployItem %s",
+	printableNode.Headline = fmt.Sprintf("[%s%s] DeployItem %s%s",
 		formatStatus(string(deployItem.DeployItem.Status.Phase)),
 		formatOutdated(check.isDeployItemOutdated(deployItem.DeployItem)),
-		deployItem.DeployItem.Name)
+		deployItem.DeployItem.Name,
+		t.formatDuration(deployItem.DeployItem))
+	printableNode.Highlighted = t.phaseChanged("DeployItem", deployItem.DeployItem, string(deployItem.DeployItem.Status.Phase))
 
 	if t.wideMode {
 		wide := strings.Builder{}
 		diType := deployItem.DeployItem.Spec.Type
 		wide.WriteString(fmt.Sprintf("Type: %s", diType))
-		switch diType {
-		case "landscaper.gardener.cloud/helm":
-			// print helm chart location
-			wide.WriteString("\n")
-			config := &helmv1alpha1.ProviderConfiguration{}
-			err := json.Unmarshal(deployItem.DeployItem.Spec.Configuration.Raw, config)
-			if err != nil {
-				wide.WriteString("unable to parse helm config")
-			} else {
-				chartLocation := ""
-				if len(config.Chart.Ref) != 0 {
-					chartLocation = config.Chart.Ref
-				} else if config.Chart.Archive != nil {
-					if len(config.Chart.Archive.Raw) != 0 {
-						chartLocation = "inline (archive)"
-					} else if config.Chart.Archive.Remote != nil {
-						chartLocation = fmt.Sprintf("%s (archive)", config.Chart.Archive.Remote.URL)
-					}
-				} else if config.Chart.FromResource != nil {
-					cd := "inline component descriptor"
-					if config.Chart.FromResource.Reference != nil {
-						cd = fmt.Sprintf("%s:%s", config.Chart.FromResource.Reference.ComponentName, config.Chart.FromResource.Reference.Version)
-					}
-					chartLocation = fmt.Sprintf("resource %q from %s", config.Chart.FromResource.ResourceName, cd)
-				}
-				if len(chartLocation) == 0 {
-					chartLocation = "unknown"
-				}
-				wide.WriteString("Chart: ")
-				wide.WriteString(chartLocation)
-			}
-		case "landscaper.gardener.cloud/container":
-			// print container image and command
+		if config := renderDeployItemConfig(deployItem.DeployItem); len(config) != 0 {
 			wide.WriteString("\n")
-			config := &containerv1alpha1.ProviderConfiguration{}
-			err := json.Unmarshal(deployItem.DeployItem.Spec.Configuration.Raw, config)
-			if err != nil {
-				wide.WriteString("unable to parse container config")
-			} else {
-				wide.WriteString("Image: ")
-				wide.WriteString(config.Image)
-				if len(config.Command) != 0 {
-					wide.WriteString("\nCommand:")
-					for _, e := range config.Command {
-						wide.WriteString(fmt.Sprintf(" %q", e))
-					}
-				}
-				if len(config.Args) != 0 {
-					wide.WriteString("\nArgs:")
-					for _, e := range config.Args {
-						wide.WriteString(fmt.Sprintf(" %q", e))
-					}
-				}
-			}
+			wide.WriteString(config)
 		}
+		wide.WriteString("\n")
+		wide.WriteString(formatTimes(deployItem.DeployItem))
 		printableNode.WideData = wide.String()
 	}
 
@@ -236,9 +225,109 @@ func (t *Transformer) transformDeployItem(deployItem *DeployItemLeaf, check *out
 		printableNode.Description = fmt.Sprintf("Last error: %s", deployItem.DeployItem.Status.LastError.Message)
 	}
 
+	for _, resource := range deployItem.Resources {
+		printableNode.Childs = append(printableNode.Childs, transformManagedResource(resource))
+	}
+
 	return &printableNode, nil
 }
 
+func transformManagedResource(resource *ManagedResource) *PrintableTreeNode {
+	ref := resource.Reference
+	name := ref.Name
+	if len(ref.Namespace) != 0 {
+		name = ref.Namespace + "/" + name
+	}
+	return &PrintableTreeNode{
+		Headline:    fmt.Sprintf("[%s] %s %s", formatReadiness(resource.Readiness), ref.Kind, name),
+		Description: resource.Status,
+	}
+}
+
+func (t *Transformer) phaseChanged(kind string, obj client.Object, phase string) bool {
+	if t.phaseTracker == nil {
+		return false
+	}
+	return t.phaseTracker.phaseChanged(kind, obj, phase)
+}
+
+// formatDuration returns the duration of the last job of the object, if durations are shown.
+func (t *Transformer) formatDuration(obj client.Object) string {
+	if !t.durations {
+		return ""
+	}
+	jobDuration, finished, ok := lastJobDurationOf(obj, time.Now())
+	switch {
+	case !ok:
+		return " (last job: unknown)"
+	case finished:
+		return fmt.Sprintf(" (last job took %s)", jobDuration)
+	default:
+		return fmt.Sprintf(" (job running for %s)", jobDuration)
+	}
+}
+
+// formatTimes returns the creation age, the last phase transition and the job IDs of an object for the wide mode.
+func formatTimes(obj client.Object) string {
+	now := time.Now()
+	phaseSince := "unknown"
+	if transitionTime := PhaseTransitionTimeOf(obj); transitionTime != nil {
+		phaseSince = fmt.Sprintf("%s (%s)", transitionTime.Format(time.RFC3339), duration.HumanDuration(now.Sub(transitionTime.Time)))
+	}
+
+	jobID, jobIDFinished := jobIDsOf(obj)
+	jobState := "finished"
+	if jobID != jobIDFinished {
+		jobState = "running"
+	}
+
+	return fmt.Sprintf("Age: %s\nPhase since: %s\nJob ID: %s, finished job ID: %s (%s)",
+		formatAge(obj.GetCreationTimestamp().Time), phaseSince, valueOrNone(jobID), valueOrNone(jobIDFinished), jobState)
+}
+
+// lastJobDurationOf returns how long the last job of an object took, or how long it has been running if it is not
+// finished yet. The job starts when the job ID is set and ends when the object reaches a final phase.
+func lastJobDurationOf(obj client.Object, now time.Time) (jobDuration time.Duration, finished bool, ok bool) {
+	var transitionTimes *lsv1alpha1.TransitionTimes
+	switch o := obj.(type) {
+	case *lsv1alpha1.Installation:
+		transitionTimes = o.Status.TransitionTimes
+	case *lsv1alpha1.Execution:
+		transitionTimes = o.Status.TransitionTimes
+	case *lsv1alpha1.DeployItem:
+		transitionTimes = o.Status.TransitionTimes
+	}
+	if transitionTimes == nil {
+		return 0, false, false
+	}
+
+	start := transitionTimes.TriggerTime
+	if start == nil {
+		start = transitionTimes.InitTime
+	}
+	if start == nil {
+		return 0, false, false
+	}
+
+	jobID, jobIDFinished := jobIDsOf(obj)
+	if jobID == jobIDFinished && transitionTimes.FinishedTime != nil && !transitionTimes.FinishedTime.Before(start) {
+		return transitionTimes.FinishedTime.Sub(start.Time).Round(time.Second), true, true
+	}
+	return now.Sub(start.Time).Round(time.Second), false, true
+}
+
+func jobIDsOf(obj client.Object) (jobID string, jobIDFinished string) {
+	switch o := obj.(type) {
+	case *lsv1alpha1.Installation:
+		return o.Status.JobID, o.Status.JobIDFinished
+	case *lsv1alpha1.Execution:
+		return o.Status.JobID, o.Status.JobIDFinished
+	case *lsv1alpha1.DeployItem:
+		return o.Status.JobID, o.Status.JobIDFinished
+	}
+	return "", ""
+}
+
 func formatStatus(status string) string {
 	switch status {
 	case string(lsv1alpha1.InstallationPhases.Succeeded):
@@ -262,6 +351,19 @@ func formatStatus(status string) string {
 	}
 }
 
+func formatReadiness(readiness string) string {
+	switch readiness {
+	case ResourceReady:
+		return "✅ " + readiness
+	case ResourceNotReady:
+		return "🏗️ " + readiness
+	case ResourceMissing:
+		return "❌ " + readiness
+	default:
+		return readiness
+	}
+}
+
 func formatOutdated(outdated bool) string {
 	if outdated {
 		return " (outdated)"
@@ -284,3 +386,15 @@ func (o *outdatedCheck) isExecutionOutdated(exec *lsv1alpha1.Execution) bool {
 func (o *outdatedCheck) isDeployItemOutdated(di *lsv1alpha1.DeployItem) bool {
 	return di.Status.JobID != o.rootJobID
 }
+
+func (o *outdatedCheck) isOutdated(obj client.Object) bool {
+	switch o2 := obj.(type) {
+	case *lsv1alpha1.Installation:
+		return o.isInstallationOutdated(o2)
+	case *lsv1alpha1.Execution:
+		return o.isExecutionOutdated(o2)
+	case *lsv1alpha1.DeployItem:
+		return o.isDeployItemOutdated(o2)
+	}
+	return false
+}
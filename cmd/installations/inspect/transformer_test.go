@@ -0,0 +1,62 @@
+package tree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLastJobDuration(t *testing.T) {
+	now := time.Now()
+	triggerTime := metav1.NewTime(now.Add(-10 * time.Minute))
+	finishedTime := metav1.NewTime(now.Add(-7 * time.Minute))
+
+	deployItem := &lsv1alpha1.DeployItem{}
+	_, _, ok := lastJobDurationOf(deployItem, now)
+	assert.False(t, ok)
+
+	deployItem.Status.JobID = "job-2"
+	deployItem.Status.JobIDFinished = "job-1"
+	deployItem.Status.TransitionTimes = &lsv1alpha1.TransitionTimes{TriggerTime: &triggerTime, FinishedTime: &finishedTime}
+	jobDuration, finished, ok := lastJobDurationOf(deployItem, now)
+	assert.True(t, ok)
+	assert.False(t, finished)
+	assert.Equal(t, 10*time.Minute, jobDuration)
+
+	deployItem.Status.JobIDFinished = "job-2"
+	jobDuration, finished, ok = lastJobDurationOf(deployItem, now)
+	assert.True(t, ok)
+	assert.True(t, finished)
+	assert.Equal(t, 3*time.Minute, jobDuration)
+
+	transformer := NewTransformer(false, false, false, false).WithDurations(true)
+	assert.Equal(t, " (last job took 3m0s)", transformer.formatDuration(deployItem))
+	assert.Equal(t, finishedTime.Time, PhaseTransitionTimeOf(deployItem).Time)
+}
+
+func TestTransformOnlyFailed(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{K8sClient: fakeClient}
+	// the root installation default/fakeinst is failed, inttest/my-aggregation is succeeded
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+
+	transformer := NewTransformer(false, true, true, false)
+	printableTrees, err := transformer.TransformToPrintableTrees(installationTrees)
+	assert.NoError(t, err)
+	assert.Empty(t, printableTrees)
+
+	allTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "", "*")
+	assert.NoError(t, err)
+	assert.Len(t, allTrees, 2)
+	printableTrees, err = transformer.TransformToPrintableTrees(allTrees)
+	assert.NoError(t, err)
+	assert.Len(t, printableTrees, 1)
+}
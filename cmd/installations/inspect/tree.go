@@ -2,6 +2,8 @@ package tree
 
 import (
 	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // InstallationTree contains the Installation and the references to Sub-Installations and the Execution.
@@ -11,20 +13,113 @@ type InstallationTree struct {
 	Installation     *lsv1alpha1.Installation `json:"installation,omitempty"`
 }
 
+// DeepCopy returns a deep copy of the installation tree, so that it can be filtered without modifying the original tree.
+func (i *InstallationTree) DeepCopy() *InstallationTree {
+	if i == nil {
+		return nil
+	}
+	out := &InstallationTree{
+		Execution:    i.Execution.DeepCopy(),
+		Installation: i.Installation.DeepCopy(),
+	}
+	for _, subInstallation := range i.SubInstallations {
+		out.SubInstallations = append(out.SubInstallations, subInstallation.DeepCopy())
+	}
+	return out
+}
+
+// UpdateObject replaces the installation, execution or deployItem of the tree that has the same namespace and name as
+// the given object. It returns false if the tree contains no such object or if the update changes the structure of the
+// tree, i.e. the tree has to be collected again.
+func (i *InstallationTree) UpdateObject(obj client.Object) bool {
+	if inst, ok := obj.(*lsv1alpha1.Installation); ok && isSameObject(i.Installation, inst) {
+		if (inst.Status.ExecutionReference != nil) != (i.Execution != nil) {
+			return false
+		}
+		i.Installation = inst
+		return true
+	}
+
+	for _, subInstallation := range i.SubInstallations {
+		if subInstallation.UpdateObject(obj) {
+			return true
+		}
+	}
+
+	return i.Execution != nil && i.Execution.updateObject(obj)
+}
+
+// ContainsParentOf returns true if the tree contains the installation or execution that the given object belongs to.
+func (i *InstallationTree) ContainsParentOf(obj client.Object) bool {
+	switch o := obj.(type) {
+	case *lsv1alpha1.Installation:
+		if o.Namespace == i.Installation.Namespace && o.Labels[lsv1alpha1.EncompassedByLabel] == i.Installation.Name {
+			return true
+		}
+	case *lsv1alpha1.Execution:
+		if o.Namespace == i.Installation.Namespace && i.Installation.Status.ExecutionReference != nil &&
+			i.Installation.Status.ExecutionReference.Name == o.Name {
+			return true
+		}
+	case *lsv1alpha1.DeployItem:
+		if i.Execution != nil && o.Namespace == i.Execution.Execution.Namespace &&
+			o.Labels[lsv1alpha1.ExecutionManagedByLabel] == i.Execution.Execution.Name {
+			return true
+		}
+	}
+
+	for _, subInstallation := range i.SubInstallations {
+		if subInstallation.ContainsParentOf(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeployItems returns the deployItems of the installation and of all its subinstallations.
+func (i *InstallationTree) DeployItems() []*lsv1alpha1.DeployItem {
+	deployItems := []*lsv1alpha1.DeployItem{}
+	i.walk(func(obj client.Object, _ string) {
+		if deployItem, ok := obj.(*lsv1alpha1.DeployItem); ok {
+			deployItems = append(deployItems, deployItem)
+		}
+	})
+	return deployItems
+}
+
+// walk calls visit for the installation and all installations, executions and deployItems below it.
+func (i *InstallationTree) walk(visit func(obj client.Object, phase string)) {
+	visit(i.Installation, string(i.Installation.Status.InstallationPhase))
+	for _, subInstallation := range i.SubInstallations {
+		subInstallation.walk(visit)
+	}
+	if i.Execution != nil {
+		i.Execution.walk(visit)
+	}
+}
+
 func (i *InstallationTree) filterForFailedInstallation() *InstallationTree {
+	return i.filter(func(_ client.Object, phase string) bool {
+		return isFailedPhase(phase)
+	})
+}
+
+// filter removes all installations, executions and deployItems from the tree for which keep returns false.
+// Parent elements of kept items are kept as well. It returns nil if no element is kept.
+func (i *InstallationTree) filter(keep objectFilter) *InstallationTree {
 	filteredSubInstallations := []*InstallationTree{}
 	for _, subInstallation := range i.SubInstallations {
-		if filteredSubInstallation := subInstallation.filterForFailedInstallation(); filteredSubInstallation != nil {
+		if filteredSubInstallation := subInstallation.filter(keep); filteredSubInstallation != nil {
 			filteredSubInstallations = append(filteredSubInstallations, filteredSubInstallation)
 		}
 	}
 	i.SubInstallations = filteredSubInstallations
 
 	if i.Execution != nil {
-		i.Execution = i.Execution.filterForFailedExecution()
+		i.Execution = i.Execution.filter(keep)
 	}
 
-	if len(filteredSubInstallations) > 0 || i.Execution != nil || i.Installation.Status.InstallationPhase.IsFailed() {
+	if len(filteredSubInstallations) > 0 || i.Execution != nil || keep(i.Installation, string(i.Installation.Status.InstallationPhase)) {
 		return i
 	}
 	return nil
@@ -37,17 +132,61 @@ type ExecutionTree struct {
 	Execution   *lsv1alpha1.Execution `json:"execution,omitempty"`
 }
 
-func (e *ExecutionTree) filterForFailedExecution() *ExecutionTree {
+// DeepCopy returns a deep copy of the execution tree.
+func (e *ExecutionTree) DeepCopy() *ExecutionTree {
+	if e == nil {
+		return nil
+	}
+	out := &ExecutionTree{
+		Execution: e.Execution.DeepCopy(),
+	}
+	for _, depItem := range e.DeployItems {
+		leaf := &DeployItemLeaf{DeployItem: depItem.DeployItem.DeepCopy()}
+		for _, resource := range depItem.Resources {
+			resourceCopy := *resource
+			leaf.Resources = append(leaf.Resources, &resourceCopy)
+		}
+		out.DeployItems = append(out.DeployItems, leaf)
+	}
+	return out
+}
+
+func (e *ExecutionTree) updateObject(obj client.Object) bool {
+	switch o := obj.(type) {
+	case *lsv1alpha1.Execution:
+		if isSameObject(e.Execution, o) {
+			e.Execution = o
+			return true
+		}
+	case *lsv1alpha1.DeployItem:
+		for _, depItem := range e.DeployItems {
+			if isSameObject(depItem.DeployItem, o) {
+				depItem.DeployItem = o
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e *ExecutionTree) walk(visit func(obj client.Object, phase string)) {
+	visit(e.Execution, string(e.Execution.Status.ExecutionPhase))
+	for _, depItem := range e.DeployItems {
+		visit(depItem.DeployItem, string(depItem.DeployItem.Status.Phase))
+	}
+}
+
+func (e *ExecutionTree) filter(keep objectFilter) *ExecutionTree {
 	filteredDeployItems := []*DeployItemLeaf{}
 	for _, depItem := range e.DeployItems {
-		if filteredDeployItem := depItem.filterForFailedDeployItem(); filteredDeployItem != nil {
+		if filteredDeployItem := depItem.filter(keep); filteredDeployItem != nil {
 			filteredDeployItems = append(filteredDeployItems, filteredDeployItem)
 		}
 	}
 
 	e.DeployItems = filteredDeployItems
 
-	if len(filteredDeployItems) > 0 || e.Execution.Status.ExecutionPhase.IsFailed() {
+	if len(filteredDeployItems) > 0 || keep(e.Execution, string(e.Execution.Status.ExecutionPhase)) {
 		return e
 	}
 	return nil
@@ -56,11 +195,93 @@ func (e *ExecutionTree) filterForFailedExecution() *ExecutionTree {
 // DeployItemLeaf contains a DeployItem.
 type DeployItemLeaf struct {
 	DeployItem *lsv1alpha1.DeployItem `json:"deployItem,omitempty"`
+	// Resources are the Kubernetes objects deployed by the deployItem. They are only set if they have been collected
+	// with Collector.CollectManagedResources.
+	Resources []*ManagedResource `json:"resources,omitempty"`
 }
 
-func (d *DeployItemLeaf) filterForFailedDeployItem() *DeployItemLeaf {
-	if d.DeployItem.Status.Phase.IsFailed() {
+func (d *DeployItemLeaf) filter(keep objectFilter) *DeployItemLeaf {
+	if keep(d.DeployItem, string(d.DeployItem.Status.Phase)) {
 		return d
 	}
 	return nil
 }
+
+// objectFilter decides whether an installation, execution or deployItem is kept when a tree is filtered.
+type objectFilter func(obj client.Object, phase string) bool
+
+// KindOf returns the kind of an installation, execution or deployItem.
+func KindOf(obj client.Object) string {
+	switch obj.(type) {
+	case *lsv1alpha1.Installation:
+		return "Installation"
+	case *lsv1alpha1.Execution:
+		return "Execution"
+	case *lsv1alpha1.DeployItem:
+		return "DeployItem"
+	case *lsv1alpha1.DataObject:
+		return "DataObject"
+	}
+	return obj.GetObjectKind().GroupVersionKind().Kind
+}
+
+// PhaseOf returns the phase of an installation, execution or deployItem.
+func PhaseOf(obj client.Object) string {
+	switch o := obj.(type) {
+	case *lsv1alpha1.Installation:
+		return string(o.Status.InstallationPhase)
+	case *lsv1alpha1.Execution:
+		return string(o.Status.ExecutionPhase)
+	case *lsv1alpha1.DeployItem:
+		return string(o.Status.Phase)
+	}
+	return ""
+}
+
+// lastErrorOf returns the last error of an installation, execution or deployItem.
+func lastErrorOf(obj client.Object) *lsv1alpha1.Error {
+	switch o := obj.(type) {
+	case *lsv1alpha1.Installation:
+		return o.Status.LastError
+	case *lsv1alpha1.Execution:
+		return o.Status.LastError
+	case *lsv1alpha1.DeployItem:
+		return o.Status.LastError
+	}
+	return nil
+}
+
+// PhaseTransitionTimeOf returns the time when an installation, execution or deployItem has entered its current phase.
+// DeployItems have no phase transition time, so the latest of their status transition times is used instead.
+// It returns nil if the object has no timestamps.
+func PhaseTransitionTimeOf(obj client.Object) *metav1.Time {
+	var phaseTransitionTime *metav1.Time
+	var transitionTimes *lsv1alpha1.TransitionTimes
+	switch o := obj.(type) {
+	case *lsv1alpha1.Installation:
+		phaseTransitionTime, transitionTimes = o.Status.PhaseTransitionTime, o.Status.TransitionTimes
+	case *lsv1alpha1.Execution:
+		phaseTransitionTime, transitionTimes = o.Status.PhaseTransitionTime, o.Status.TransitionTimes
+	case *lsv1alpha1.DeployItem:
+		transitionTimes = o.Status.TransitionTimes
+	}
+
+	if phaseTransitionTime != nil || transitionTimes == nil {
+		return phaseTransitionTime
+	}
+	var latest *metav1.Time
+	for _, t := range []*metav1.Time{transitionTimes.TriggerTime, transitionTimes.InitTime, transitionTimes.WaitTime, transitionTimes.FinishedTime} {
+		if t != nil && (latest == nil || latest.Before(t)) {
+			latest = t
+		}
+	}
+	return latest
+}
+
+func isFailedPhase(phase string) bool {
+	return lsv1alpha1.InstallationPhase(phase).IsFailed()
+}
+
+func isSameObject(a, b client.Object) bool {
+	return a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+}
@@ -0,0 +1,56 @@
+package tree
+
+import (
+	"context"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateObject(t *testing.T) {
+	fakeClient, state, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{
+		K8sClient: fakeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+
+	transformer := NewTransformer(false, false, false, false).WithPhaseTracker(NewPhaseTracker())
+	printableTrees, err := transformer.TransformToPrintableTrees([]*InstallationTree{installationTrees[0].DeepCopy()})
+	assert.NoError(t, err)
+
+	t.Run("Nothing is highlighted in the first transformation", func(t *testing.T) {
+		assert.False(t, printableTrees[0].Highlighted)
+		assert.False(t, printableTrees[0].Childs[1].Childs[0].Childs[0].Highlighted)
+	})
+
+	updatedDeployItem := state.DeployItems["inttest/server-gw64l-deploy-7mhc2"].DeepCopy()
+	updatedDeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Progressing
+
+	t.Run("Update of a deployItem", func(t *testing.T) {
+		assert.True(t, installationTrees[0].UpdateObject(updatedDeployItem))
+		assert.Equal(t, updatedDeployItem, installationTrees[0].SubInstallations[1].Execution.DeployItems[0].DeployItem)
+	})
+
+	printableTrees, err = transformer.TransformToPrintableTrees([]*InstallationTree{installationTrees[0].DeepCopy()})
+	assert.NoError(t, err)
+
+	t.Run("Only the changed deployItem is highlighted", func(t *testing.T) {
+		assert.False(t, printableTrees[0].Highlighted)
+		assert.False(t, printableTrees[0].Childs[0].Childs[0].Childs[0].Highlighted)
+		assert.True(t, printableTrees[0].Childs[1].Childs[0].Childs[0].Highlighted)
+	})
+
+	unknownDeployItem := updatedDeployItem.DeepCopy()
+	unknownDeployItem.Name = "unknown"
+	unknownDeployItem.Labels[lsv1alpha1.ExecutionManagedByLabel] = "server-gw64l"
+
+	t.Run("Unknown deployItem of a known execution", func(t *testing.T) {
+		assert.False(t, installationTrees[0].UpdateObject(unknownDeployItem))
+		assert.True(t, installationTrees[0].ContainsParentOf(unknownDeployItem))
+	})
+}
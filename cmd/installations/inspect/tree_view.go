@@ -0,0 +1,314 @@
+package tree
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	expandedMarker  = "▾ "
+	collapsedMarker = "▸ "
+	leafMarker      = "  "
+)
+
+// TreeView is the navigation state of the interactive inspect mode. It shows the headlines of printable trees, whose
+// nodes can be expanded and collapsed, and keeps track of the selected node. Initially, only the root nodes are
+// expanded.
+type TreeView struct {
+	roots    []*PrintableTreeNode
+	expanded map[string]bool
+	rows     []TreeViewRow
+	cursor   int
+	offset   int
+}
+
+// TreeViewRow is a visible line of a TreeView.
+type TreeViewRow struct {
+	Node *PrintableTreeNode
+	// Prefix contains the tree characters in front of the node.
+	Prefix string
+	// Parent is the index of the row of the parent node, or -1 for root nodes.
+	Parent int
+
+	key string
+}
+
+// treeViewNode is a node together with its ancestors, used to search nodes which are not visible.
+type treeViewNode struct {
+	node      *PrintableTreeNode
+	key       string
+	ancestors []string
+}
+
+func NewTreeView(trees []PrintableTreeNode) *TreeView {
+	v := &TreeView{expanded: map[string]bool{}}
+	v.setRoots(trees)
+	for i, root := range v.roots {
+		v.expanded[nodeKey("", i, root)] = true
+	}
+	v.buildRows()
+	return v
+}
+
+// SetTrees replaces the displayed trees, e.g. after the installations have been collected again. Expanded nodes stay
+// expanded and the selection stays on the same node if it still exists.
+func (v *TreeView) SetTrees(trees []PrintableTreeNode) {
+	selectedKey := ""
+	if len(v.rows) > 0 {
+		selectedKey = v.rows[v.cursor].key
+	}
+
+	v.setRoots(trees)
+	v.buildRows()
+	v.selectKey(selectedKey)
+}
+
+func (v *TreeView) setRoots(trees []PrintableTreeNode) {
+	v.roots = make([]*PrintableTreeNode, 0, len(trees))
+	for i := range trees {
+		v.roots = append(v.roots, &trees[i])
+	}
+}
+
+// nodeKey identifies a node across refreshes. Nodes of installations, executions and deployItems are identified by
+// their object, other nodes by their position below their parent.
+func nodeKey(parentKey string, index int, node *PrintableTreeNode) string {
+	if node.Object != nil {
+		return fmt.Sprintf("%s/%s/%s", KindOf(node.Object), node.Object.GetNamespace(), node.Object.GetName())
+	}
+	return fmt.Sprintf("%s#%d", parentKey, index)
+}
+
+func (v *TreeView) buildRows() {
+	v.rows = []TreeViewRow{}
+	var add func(node *PrintableTreeNode, key, prefix, childPrefix string, parent int)
+	add = func(node *PrintableTreeNode, key, prefix, childPrefix string, parent int) {
+		row := len(v.rows)
+		v.rows = append(v.rows, TreeViewRow{Node: node, Prefix: prefix, Parent: parent, key: key})
+		if !v.expanded[key] {
+			return
+		}
+		for i, child := range node.Childs {
+			if i == len(node.Childs)-1 {
+				add(child, nodeKey(key, i, child), childPrefix+lastItem, childPrefix+emptySpace, row)
+			} else {
+				add(child, nodeKey(key, i, child), childPrefix+middleItem, childPrefix+continueItem, row)
+			}
+		}
+	}
+	for i, root := range v.roots {
+		add(root, nodeKey("", i, root), "", "", -1)
+	}
+
+	if v.cursor >= len(v.rows) {
+		v.cursor = len(v.rows) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+func (v *TreeView) selectKey(key string) bool {
+	for i, row := range v.rows {
+		if row.key == key {
+			v.cursor = i
+			return true
+		}
+	}
+	return false
+}
+
+// Rows returns the visible rows.
+func (v *TreeView) Rows() []TreeViewRow {
+	return v.rows
+}
+
+// Cursor returns the index of the selected row.
+func (v *TreeView) Cursor() int {
+	return v.cursor
+}
+
+// Selected returns the selected node, or nil if there are no nodes.
+func (v *TreeView) Selected() *PrintableTreeNode {
+	if len(v.rows) == 0 {
+		return nil
+	}
+	return v.rows[v.cursor].Node
+}
+
+// IsRoot returns whether the selected node is a root node, e.g. a root installation.
+func (v *TreeView) IsRoot() bool {
+	return len(v.rows) > 0 && v.rows[v.cursor].Parent == -1
+}
+
+// Move moves the selection by delta rows.
+func (v *TreeView) Move(delta int) {
+	v.cursor += delta
+	if v.cursor >= len(v.rows) {
+		v.cursor = len(v.rows) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+}
+
+// Toggle expands the selected node if it is collapsed and collapses it otherwise.
+func (v *TreeView) Toggle() {
+	if len(v.rows) == 0 || len(v.rows[v.cursor].Node.Childs) == 0 {
+		return
+	}
+	key := v.rows[v.cursor].key
+	v.expanded[key] = !v.expanded[key]
+	v.buildRows()
+}
+
+// Expand expands the selected node. If it is expanded already, the first child is selected.
+func (v *TreeView) Expand() {
+	if len(v.rows) == 0 || len(v.rows[v.cursor].Node.Childs) == 0 {
+		return
+	}
+	key := v.rows[v.cursor].key
+	if v.expanded[key] {
+		v.Move(1)
+		return
+	}
+	v.expanded[key] = true
+	v.buildRows()
+}
+
+// Collapse collapses the selected node. If it is collapsed already or has no children, its parent is selected.
+func (v *TreeView) Collapse() {
+	if len(v.rows) == 0 {
+		return
+	}
+	row := v.rows[v.cursor]
+	if len(row.Node.Childs) > 0 && v.expanded[row.key] {
+		v.expanded[row.key] = false
+		v.buildRows()
+		return
+	}
+	if row.Parent >= 0 {
+		v.cursor = row.Parent
+	}
+}
+
+// SetAllExpanded expands or collapses all nodes. Root nodes stay expanded.
+func (v *TreeView) SetAllExpanded(expanded bool) {
+	selectedKey := ""
+	if len(v.rows) > 0 {
+		selectedKey = v.rows[v.cursor].key
+	}
+
+	v.expanded = map[string]bool{}
+	for _, n := range v.allNodes() {
+		if expanded || len(n.ancestors) == 0 {
+			v.expanded[n.key] = true
+		}
+	}
+	v.buildRows()
+
+	if !v.selectKey(selectedKey) {
+		// the selected node is hidden now, so its nearest visible ancestor is selected
+		for _, n := range v.allNodes() {
+			if n.key != selectedKey {
+				continue
+			}
+			for a := len(n.ancestors) - 1; a >= 0; a-- {
+				if v.selectKey(n.ancestors[a]) {
+					break
+				}
+			}
+		}
+	}
+}
+
+// NextFailed selects the next node after the selected one whose object is in a failed phase. The search wraps around
+// at the end and the ancestors of the found node are expanded. It returns false if there is no failed node.
+func (v *TreeView) NextFailed() bool {
+	nodes := v.allNodes()
+	if len(nodes) == 0 {
+		return false
+	}
+
+	start := 0
+	if len(v.rows) > 0 {
+		for i, n := range nodes {
+			if n.key == v.rows[v.cursor].key {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	for i := 0; i < len(nodes); i++ {
+		n := nodes[(start+i)%len(nodes)]
+		if n.node.Object == nil || !isFailedPhase(PhaseOf(n.node.Object)) {
+			continue
+		}
+		for _, ancestor := range n.ancestors {
+			v.expanded[ancestor] = true
+		}
+		v.buildRows()
+		v.selectKey(n.key)
+		return true
+	}
+	return false
+}
+
+// allNodes returns all nodes in tree order, including the nodes below collapsed nodes.
+func (v *TreeView) allNodes() []treeViewNode {
+	nodes := []treeViewNode{}
+	var add func(node *PrintableTreeNode, key string, ancestors []string)
+	add = func(node *PrintableTreeNode, key string, ancestors []string) {
+		nodes = append(nodes, treeViewNode{node: node, key: key, ancestors: ancestors})
+		childAncestors := append(append([]string{}, ancestors...), key)
+		for i, child := range node.Childs {
+			add(child, nodeKey(key, i, child), childAncestors)
+		}
+	}
+	for i, root := range v.roots {
+		add(root, nodeKey("", i, root), nil)
+	}
+	return nodes
+}
+
+// Render returns the visible rows for a screen of the given size. The view scrolls so that the selected row is
+// visible, and the selected row is displayed in reverse video.
+func (v *TreeView) Render(width, height int) []string {
+	if height <= 0 {
+		return nil
+	}
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+height {
+		v.offset = v.cursor - height + 1
+	}
+
+	lines := []string{}
+	for i := v.offset; i < len(v.rows) && i < v.offset+height; i++ {
+		row := v.rows[i]
+		marker := leafMarker
+		if len(row.Node.Childs) > 0 {
+			marker = collapsedMarker
+			if v.expanded[row.key] {
+				marker = expandedMarker
+			}
+		}
+		line := TruncateLine(row.Prefix+marker+row.Node.Headline, width)
+		if i == v.cursor {
+			line = highlightStart + line + highlightEnd
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// TruncateLine shortens a line to the given number of characters.
+func TruncateLine(line string, width int) string {
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return line
+	}
+	return strings.TrimRight(string(runes[:width-1]), " ") + "…"
+}
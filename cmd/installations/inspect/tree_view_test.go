@@ -0,0 +1,92 @@
+package tree
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeView(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	collector := Collector{K8sClient: fakeClient}
+	installationTrees, err := collector.CollectInstallationsInCluster(context.TODO(), "my-aggregation", "inttest")
+	assert.NoError(t, err)
+	// the server deployItem has failed
+	serverDeployItem := installationTrees[0].SubInstallations[1].Execution.DeployItems[0].DeployItem
+	serverDeployItem.Status.Phase = lsv1alpha1.DeployItemPhases.Failed
+
+	transform := func() []PrintableTreeNode {
+		printableTrees, err := NewTransformer(false, false, false, false).TransformToPrintableTrees(installationTrees)
+		assert.NoError(t, err)
+		return printableTrees
+	}
+	headlines := func(view *TreeView) []string {
+		result := []string{}
+		for _, row := range view.Rows() {
+			result = append(result, row.Prefix+strings.SplitN(row.Node.Headline, "] ", 2)[1])
+		}
+		return result
+	}
+
+	// initially, only the root installation is expanded
+	view := NewTreeView(transform())
+	assert.Equal(t, []string{
+		"Installation my-aggregation",
+		"├── Installation ingress-hhsjf",
+		"└── Installation server-gw64l",
+	}, headlines(view))
+	assert.True(t, view.IsRoot())
+
+	view.Move(2)
+	view.Expand()
+	assert.Equal(t, []string{
+		"Installation my-aggregation",
+		"├── Installation ingress-hhsjf",
+		"└── Installation server-gw64l",
+		"    └── Execution server-gw64l",
+	}, headlines(view))
+	assert.False(t, view.IsRoot())
+
+	// collapse selects the parent of a leaf
+	view.Expand()
+	assert.Equal(t, "Execution", KindOf(view.Selected().Object))
+	view.Collapse()
+	assert.Equal(t, "server-gw64l", view.Selected().Object.GetName())
+	view.Collapse()
+	assert.Len(t, view.Rows(), 3)
+
+	// the next failed node is found below collapsed nodes
+	view.Move(-10)
+	assert.True(t, view.NextFailed())
+	assert.Equal(t, serverDeployItem.Name, view.Selected().Object.GetName())
+	assert.Equal(t, 4, view.Cursor())
+	// the search wraps around
+	assert.True(t, view.NextFailed())
+	assert.Equal(t, 4, view.Cursor())
+
+	// the state is kept when the trees are replaced
+	view.SetTrees(transform())
+	assert.Equal(t, serverDeployItem.Name, view.Selected().Object.GetName())
+	assert.Len(t, view.Rows(), 5)
+
+	view.SetAllExpanded(true)
+	assert.Len(t, view.Rows(), 7)
+	// root nodes stay expanded and the selection moves to the nearest visible ancestor of the hidden node
+	view.SetAllExpanded(false)
+	assert.Len(t, view.Rows(), 3)
+	assert.Equal(t, "server-gw64l", view.Selected().Object.GetName())
+
+	// rendering scrolls to the selected row and truncates long lines
+	view.SetAllExpanded(true)
+	view.Move(10)
+	lines := view.Render(20, 3)
+	assert.Len(t, lines, 3)
+	assert.Equal(t, highlightStart+TruncateLine(view.Rows()[6].Prefix+leafMarker+view.Rows()[6].Node.Headline, 20)+highlightEnd, lines[2])
+	assert.Equal(t, 20, len([]rune(TruncateLine(strings.Repeat("x", 30), 20))))
+}
@@ -0,0 +1,248 @@
+package tree
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	containerv1alpha1 "github.com/gardener/landscaper/apis/deployer/container/v1alpha1"
+	helmv1alpha1 "github.com/gardener/landscaper/apis/deployer/helm/v1alpha1"
+	mockv1alpha1 "github.com/gardener/landscaper/apis/deployer/mock/v1alpha1"
+	health "github.com/gardener/landscaper/apis/deployer/utils/readinesschecks"
+)
+
+// Types of the deployItems of the landscaper deployers.
+const (
+	HelmDeployItemType      = "landscaper.gardener.cloud/helm"
+	ManifestDeployItemType  = "landscaper.gardener.cloud/kubernetes-manifest"
+	ContainerDeployItemType = "landscaper.gardener.cloud/container"
+	MockDeployItemType      = "landscaper.gardener.cloud/mock"
+)
+
+// WideRenderer describes the configuration of a deployItem in '-o wide' mode. The result may consist of several lines.
+type WideRenderer func(deployItem *lsv1alpha1.DeployItem) string
+
+// wideRenderers contains the renderers of the known deployItem types. DeployItems of other types are described by the
+// keys of their configuration.
+var wideRenderers = map[lsv1alpha1.DeployItemType]WideRenderer{
+	HelmDeployItemType:      renderHelmConfig,
+	ContainerDeployItemType: renderContainerConfig,
+	ManifestDeployItemType:  renderManifestConfig,
+	MockDeployItemType:      renderMockConfig,
+}
+
+// RegisterWideRenderer registers the renderer for a deployItem type, e.g. of a custom deployer. An existing renderer
+// of the type is replaced.
+func RegisterWideRenderer(deployItemType lsv1alpha1.DeployItemType, renderer WideRenderer) {
+	wideRenderers[deployItemType] = renderer
+}
+
+// renderDeployItemConfig describes the configuration of a deployItem with the renderer of its type.
+func renderDeployItemConfig(deployItem *lsv1alpha1.DeployItem) string {
+	if deployItem.Spec.Configuration == nil {
+		return ""
+	}
+	if renderer, ok := wideRenderers[deployItem.Spec.Type]; ok {
+		return renderer(deployItem)
+	}
+	return renderConfigKeys(deployItem)
+}
+
+// renderConfigKeys lists the top level keys of the configuration of a deployItem of an unknown type.
+func renderConfigKeys(deployItem *lsv1alpha1.DeployItem) string {
+	if len(deployItem.Spec.Configuration.Raw) == 0 {
+		return ""
+	}
+
+	config := map[string]json.RawMessage{}
+	if err := json.Unmarshal(deployItem.Spec.Configuration.Raw, &config); err != nil {
+		return "unable to parse config"
+	}
+
+	keys := []string{}
+	for key := range config {
+		if key != "apiVersion" && key != "kind" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("Config keys: %s", strings.Join(keys, ", "))
+}
+
+func renderHelmConfig(deployItem *lsv1alpha1.DeployItem) string {
+	// print helm chart location
+	config := &helmv1alpha1.ProviderConfiguration{}
+	err := json.Unmarshal(deployItem.Spec.Configuration.Raw, config)
+	if err != nil {
+		return "unable to parse helm config"
+	}
+
+	chartLocation := ""
+	if len(config.Chart.Ref) != 0 {
+		chartLocation = config.Chart.Ref
+	} else if config.Chart.Archive != nil {
+		if len(config.Chart.Archive.Raw) != 0 {
+			chartLocation = "inline (archive)"
+		} else if config.Chart.Archive.Remote != nil {
+			chartLocation = fmt.Sprintf("%s (archive)", config.Chart.Archive.Remote.URL)
+		}
+	} else if config.Chart.FromResource != nil {
+		cd := "inline component descriptor"
+		if config.Chart.FromResource.Reference != nil {
+			cd = fmt.Sprintf("%s:%s", config.Chart.FromResource.Reference.ComponentName, config.Chart.FromResource.Reference.Version)
+		}
+		chartLocation = fmt.Sprintf("resource %q from %s", config.Chart.FromResource.ResourceName, cd)
+	}
+	if len(chartLocation) == 0 {
+		chartLocation = "unknown"
+	}
+	return "Chart: " + chartLocation
+}
+
+func renderContainerConfig(deployItem *lsv1alpha1.DeployItem) string {
+	// print container image and command
+	config := &containerv1alpha1.ProviderConfiguration{}
+	err := json.Unmarshal(deployItem.Spec.Configuration.Raw, config)
+	if err != nil {
+		return "unable to parse container config"
+	}
+
+	wide := strings.Builder{}
+	wide.WriteString("Image: ")
+	wide.WriteString(config.Image)
+	if len(config.Command) != 0 {
+		wide.WriteString("\nCommand:")
+		for _, e := range config.Command {
+			wide.WriteString(fmt.Sprintf(" %q", e))
+		}
+	}
+	if len(config.Args) != 0 {
+		wide.WriteString("\nArgs:")
+		for _, e := range config.Args {
+			wide.WriteString(fmt.Sprintf(" %q", e))
+		}
+	}
+	return wide.String()
+}
+
+// manifestConfig contains the fields of the v1alpha1 and v1alpha2 manifest provider configurations which are printed.
+// In v1alpha1 the manifests are plain objects, in v1alpha2 they are wrapped together with their policy.
+type manifestConfig struct {
+	UpdateStrategy  string                             `json:"updateStrategy,omitempty"`
+	ReadinessChecks health.ReadinessCheckConfiguration `json:"readinessChecks,omitempty"`
+	Manifests       []struct {
+		Kind     string `json:"kind,omitempty"`
+		Manifest *struct {
+			Kind string `json:"kind,omitempty"`
+		} `json:"manifest,omitempty"`
+	} `json:"manifests,omitempty"`
+}
+
+func renderManifestConfig(deployItem *lsv1alpha1.DeployItem) string {
+	config := &manifestConfig{}
+	err := json.Unmarshal(deployItem.Spec.Configuration.Raw, config)
+	if err != nil {
+		return "unable to parse manifest config"
+	}
+
+	wide := strings.Builder{}
+	target := "none"
+	if deployItem.Spec.Target != nil {
+		target = deployItem.Spec.Target.Name
+	}
+	wide.WriteString(fmt.Sprintf("Target: %s", target))
+
+	// count the manifests per kind, in the order of their first occurrence
+	kinds := []string{}
+	kindCount := map[string]int{}
+	for _, manifest := range config.Manifests {
+		kind := manifest.Kind
+		if manifest.Manifest != nil {
+			kind = manifest.Manifest.Kind
+		}
+		if len(kind) == 0 {
+			kind = "unknown"
+		}
+		if kindCount[kind] == 0 {
+			kinds = append(kinds, kind)
+		}
+		kindCount[kind]++
+	}
+	for i, kind := range kinds {
+		if kindCount[kind] > 1 {
+			kinds[i] = fmt.Sprintf("%d %s", kindCount[kind], kind)
+		}
+	}
+	wide.WriteString(fmt.Sprintf("\nManifests: %d", len(config.Manifests)))
+	if len(kinds) != 0 {
+		wide.WriteString(fmt.Sprintf(" (%s)", strings.Join(kinds, ", ")))
+	}
+
+	updateStrategy := config.UpdateStrategy
+	if len(updateStrategy) == 0 {
+		updateStrategy = "update"
+	}
+	wide.WriteString(fmt.Sprintf("\nUpdate strategy: %s", updateStrategy))
+
+	readinessChecks := "default"
+	if config.ReadinessChecks.DisableDefault {
+		readinessChecks = "default disabled"
+	}
+	customChecks := []string{}
+	for _, check := range config.ReadinessChecks.CustomReadinessChecks {
+		if check.Disabled {
+			customChecks = append(customChecks, check.Name+" (disabled)")
+		} else {
+			customChecks = append(customChecks, check.Name)
+		}
+	}
+	if len(customChecks) != 0 {
+		readinessChecks = fmt.Sprintf("%s, custom: %s", readinessChecks, strings.Join(customChecks, ", "))
+	}
+	wide.WriteString(fmt.Sprintf("\nReadiness checks: %s", readinessChecks))
+
+	// the timeout of the deployItem limits the whole deployment, not only the readiness checks
+	if deployItem.Spec.Timeout != nil {
+		wide.WriteString(fmt.Sprintf("\nDeployItem timeout: %s", deployItem.Spec.Timeout.Duration))
+	}
+
+	return wide.String()
+}
+
+func renderMockConfig(deployItem *lsv1alpha1.DeployItem) string {
+	config := &mockv1alpha1.ProviderConfiguration{}
+	err := json.Unmarshal(deployItem.Spec.Configuration.Raw, config)
+	if err != nil {
+		return "unable to parse mock config"
+	}
+
+	formatPhase := func(phase *lsv1alpha1.DeployItemPhase) string {
+		if phase == nil {
+			return "not set"
+		}
+		return string(*phase)
+	}
+
+	wide := strings.Builder{}
+	wide.WriteString(fmt.Sprintf("Phase: %s, initial phase: %s", formatPhase(config.Phase), formatPhase(config.InitialPhase)))
+	wide.WriteString(fmt.Sprintf("\nProvider status: %t", config.ProviderStatus != nil))
+	if config.Export != nil {
+		exports := map[string]json.RawMessage{}
+		if err := json.Unmarshal(*config.Export, &exports); err != nil {
+			wide.WriteString("\nExport: not an object")
+		} else {
+			keys := []string{}
+			for key := range exports {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			wide.WriteString(fmt.Sprintf("\nExport keys: %s", strings.Join(keys, ", ")))
+		}
+	}
+	return wide.String()
+}
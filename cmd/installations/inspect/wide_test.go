@@ -0,0 +1,75 @@
+package tree
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestRenderDeployItemConfig(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	// v1alpha1 manifest configuration with plain manifests
+	deployItem := &lsv1alpha1.DeployItem{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Name: "server-gw64l-deploy-7mhc2", Namespace: "inttest"}, deployItem))
+	assert.Equal(t, "Target: qw74tlwijt5otemoh5alx55e2hnvcglt\n"+
+		"Manifests: 3 (Deployment, Service, Ingress)\n"+
+		"Update strategy: patch\n"+
+		"Readiness checks: default", renderDeployItemConfig(deployItem))
+
+	// v1alpha2 manifest configuration with wrapped manifests
+	deployItem.Spec.Target = nil
+	deployItem.Spec.Timeout = &lsv1alpha1.Duration{Duration: 10 * time.Minute}
+	deployItem.Spec.Configuration = &runtime.RawExtension{Raw: []byte(`{
+		"apiVersion": "manifest.deployer.landscaper.gardener.cloud/v1alpha2",
+		"kind": "ProviderConfiguration",
+		"updateStrategy": "mergeOverwrite",
+		"readinessChecks": {"disableDefault": true, "custom": [{"name": "ready"}, {"name": "old", "disabled": true}]},
+		"manifests": [
+			{"policy": "manage", "manifest": {"apiVersion": "v1", "kind": "ConfigMap"}},
+			{"policy": "manage", "manifest": {"apiVersion": "v1", "kind": "ConfigMap"}}
+		]}`)}
+	assert.Equal(t, "Target: none\n"+
+		"Manifests: 2 (2 ConfigMap)\n"+
+		"Update strategy: mergeOverwrite\n"+
+		"Readiness checks: default disabled, custom: ready, old (disabled)\n"+
+		"DeployItem timeout: 10m0s", renderDeployItemConfig(deployItem))
+
+	mockItem := &lsv1alpha1.DeployItem{
+		Spec: lsv1alpha1.DeployItemSpec{
+			Type: MockDeployItemType,
+			Configuration: &runtime.RawExtension{Raw: []byte(`{
+				"apiVersion": "mock.deployer.landscaper.gardener.cloud/v1alpha1",
+				"kind": "ProviderConfiguration",
+				"phase": "Succeeded",
+				"export": {"url": "http://example.com", "caData": "abc"}}`)},
+		},
+	}
+	assert.Equal(t, "Phase: Succeeded, initial phase: not set\n"+
+		"Provider status: false\n"+
+		"Export keys: caData, url", renderDeployItemConfig(mockItem))
+
+	// unknown types are described by their configuration keys, unless a renderer is registered
+	customItem := &lsv1alpha1.DeployItem{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+		Spec: lsv1alpha1.DeployItemSpec{
+			Type:          "example.com/terraform",
+			Configuration: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "Config", "variables": {}, "module": "vpc"}`)},
+		},
+	}
+	assert.Equal(t, "Config keys: module, variables", renderDeployItemConfig(customItem))
+
+	RegisterWideRenderer("example.com/terraform", func(deployItem *lsv1alpha1.DeployItem) string {
+		return "Terraform: " + deployItem.Name
+	})
+	defer delete(wideRenderers, "example.com/terraform")
+	assert.Equal(t, "Terraform: custom", renderDeployItemConfig(customItem))
+}
@@ -0,0 +1,565 @@
+package installations
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+const (
+	enterAlternateScreen = "\033[?1049h\033[?25l"
+	leaveAlternateScreen = "\033[?25h\033[?1049l"
+
+	// interactiveLogTailLines is the number of log lines per container which are shown in the interactive mode
+	interactiveLogTailLines = 200
+)
+
+const interactiveHelp = `Navigation
+  up/k, down/j          select the previous/next item
+  page up, page down    scroll one page
+  home, end             select the first/last item
+  right/l, left/h       expand the item, collapse the item or select its parent
+  enter, space          expand or collapse the item
+  +, -                  expand/collapse all items
+  f                     select the next failed item
+
+Details
+  d                     show the yaml of the item
+  e                     show the events of the item
+  L                     show the logs of a container deployItem
+
+Actions on root installations
+  R                     reconcile the installation
+  I                     interrupt the installation
+
+Other
+  r                     collect the installations again
+  ?                     show this help
+  q                     go back from a detail page, or quit
+  ctrl+c                quit`
+
+// escapeSequences maps the escape sequences of the terminal to key names.
+var escapeSequences = map[string]string{
+	"\x1b[A":  "up",
+	"\x1b[B":  "down",
+	"\x1b[C":  "right",
+	"\x1b[D":  "left",
+	"\x1bOA":  "up",
+	"\x1bOB":  "down",
+	"\x1bOC":  "right",
+	"\x1bOD":  "left",
+	"\x1b[5~": "pgup",
+	"\x1b[6~": "pgdn",
+	"\x1b[H":  "home",
+	"\x1b[F":  "end",
+	"\x1b[1~": "home",
+	"\x1b[4~": "end",
+}
+
+type interactiveMode int
+
+const (
+	interactiveTreeMode interactiveMode = iota
+	interactivePagerMode
+	interactiveConfirmMode
+)
+
+// interactiveInspector is the full screen terminal UI of 'installations inspect --interactive'.
+type interactiveInspector struct {
+	opts      *statusOptions
+	k8sClient client.Client
+	collector *inspect.Collector
+	filter    *inspect.TreeFilter
+	offline   bool
+	out       io.Writer
+
+	view    *inspect.TreeView
+	mode    interactiveMode
+	message string
+
+	// pager is the detail page which is shown in pager mode
+	pager *textPager
+	// confirmPrompt and confirmedAction are set in confirm mode
+	confirmPrompt   string
+	confirmedAction func() (string, error)
+
+	hostClientset kubernetes.Interface
+}
+
+// textPager is a scrollable page of text, e.g. the yaml of an object.
+type textPager struct {
+	title  string
+	lines  []string
+	offset int
+}
+
+func (o *statusOptions) runInteractive(ctx context.Context, cmd *cobra.Command, k8sClient client.Client, collector *inspect.Collector, filter *inspect.TreeFilter) error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the --interactive option requires a terminal")
+	}
+
+	i := &interactiveInspector{
+		opts:      o,
+		k8sClient: k8sClient,
+		collector: collector,
+		filter:    filter,
+		offline:   o.fromFile != "" || o.fromDir != "",
+		out:       cmd.OutOrStdout(),
+	}
+	if err := i.refresh(ctx); err != nil {
+		return err
+	}
+	// progress messages would disturb the screen
+	i.collector.Progress = nil
+
+	oldState, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("cannot switch terminal to raw mode: %w", err)
+	}
+	defer func() {
+		_ = term.Restore(fd, oldState)
+	}()
+	fmt.Fprint(i.out, enterAlternateScreen)
+	defer fmt.Fprint(i.out, leaveAlternateScreen)
+
+	keys := readKeys(os.Stdin)
+	for {
+		i.draw(fd)
+		select {
+		case <-ctx.Done():
+			return nil
+		case key, ok := <-keys:
+			if !ok || i.handleKey(ctx, key) {
+				return nil
+			}
+		}
+	}
+}
+
+// refresh collects the installations again and updates the tree view.
+func (i *interactiveInspector) refresh(ctx context.Context) error {
+	installationTrees, err := i.collector.CollectInstallationsInCluster(ctx, i.opts.installationName, i.opts.namespace)
+	if err != nil {
+		return fmt.Errorf("cannot collect installation: %w", err)
+	}
+	installationTrees = i.filter.FilterTrees(installationTrees)
+
+	if i.opts.showResources {
+		if err := i.collector.CollectManagedResources(ctx, installationTrees, nil); err != nil {
+			return fmt.Errorf("cannot collect managed resources: %w", err)
+		}
+	}
+
+	transformer := inspect.NewTransformer(false, i.opts.showOnlyFailed, i.opts.allNamespaces, false).
+		WithDurations(i.opts.durations)
+	transformedTrees, err := transformer.TransformToPrintableTrees(installationTrees)
+	if err != nil {
+		return fmt.Errorf("error transforming CR to printable tree: %w", err)
+	}
+
+	if i.view == nil {
+		i.view = inspect.NewTreeView(transformedTrees)
+	} else {
+		i.view.SetTrees(transformedTrees)
+	}
+	return nil
+}
+
+// handleKey processes a key press. It returns true if the UI should be closed.
+func (i *interactiveInspector) handleKey(ctx context.Context, key string) bool {
+	if key == "ctrl+c" {
+		return true
+	}
+
+	switch i.mode {
+	case interactivePagerMode:
+		i.handlePagerKey(key)
+		return false
+	case interactiveConfirmMode:
+		i.mode = interactiveTreeMode
+		if key == "y" || key == "Y" {
+			message, err := i.confirmedAction()
+			if err != nil {
+				i.message = "Error: " + err.Error()
+			} else {
+				i.message = message
+			}
+		} else {
+			i.message = "Cancelled"
+		}
+		return false
+	}
+
+	i.message = ""
+	switch key {
+	case "q":
+		return true
+	case "up", "k":
+		i.view.Move(-1)
+	case "down", "j":
+		i.view.Move(1)
+	case "pgup":
+		i.view.Move(-i.pageSize())
+	case "pgdn":
+		i.view.Move(i.pageSize())
+	case "home":
+		i.view.Move(-len(i.view.Rows()))
+	case "end":
+		i.view.Move(len(i.view.Rows()))
+	case "right", "l":
+		i.view.Expand()
+	case "left", "h":
+		i.view.Collapse()
+	case "enter", " ":
+		i.view.Toggle()
+	case "+":
+		i.view.SetAllExpanded(true)
+	case "-":
+		i.view.SetAllExpanded(false)
+	case "f":
+		if !i.view.NextFailed() {
+			i.message = "There are no failed items"
+		}
+	case "d":
+		i.showDetails()
+	case "e":
+		i.showEvents(ctx)
+	case "L":
+		i.showLogs(ctx)
+	case "R":
+		i.confirmOperation(ctx, "reconcile", lsv1alpha1.ReconcileOperation)
+	case "I":
+		i.confirmOperation(ctx, "interrupt", lsv1alpha1.InterruptOperation)
+	case "r":
+		if err := i.refresh(ctx); err != nil {
+			i.message = "Error: " + err.Error()
+		} else {
+			i.message = "Refreshed"
+		}
+	case "?":
+		i.showPager("Help", interactiveHelp)
+	}
+	return false
+}
+
+func (i *interactiveInspector) handlePagerKey(key string) {
+	maxOffset := len(i.pager.lines) - i.pageSize()
+	switch key {
+	case "q", "esc", "left", "h":
+		i.mode = interactiveTreeMode
+		return
+	case "up", "k":
+		i.pager.offset--
+	case "down", "j":
+		i.pager.offset++
+	case "pgup":
+		i.pager.offset -= i.pageSize()
+	case "pgdn", " ":
+		i.pager.offset += i.pageSize()
+	case "home":
+		i.pager.offset = 0
+	case "end":
+		i.pager.offset = maxOffset
+	}
+	if i.pager.offset > maxOffset {
+		i.pager.offset = maxOffset
+	}
+	if i.pager.offset < 0 {
+		i.pager.offset = 0
+	}
+}
+
+func (i *interactiveInspector) selectedObject() client.Object {
+	node := i.view.Selected()
+	if node == nil || node.Object == nil {
+		i.message = "Select an installation, execution or deployItem"
+		return nil
+	}
+	return node.Object
+}
+
+func (i *interactiveInspector) showPager(title, text string) {
+	width, _ := i.screenSize(int(os.Stdin.Fd()))
+	i.pager = &textPager{
+		title: title,
+		lines: wrapLines(strings.Split(strings.TrimRight(text, "\n"), "\n"), width),
+	}
+	i.mode = interactivePagerMode
+}
+
+// showDetails shows the yaml of the selected object, like the --show-details flag.
+func (i *interactiveInspector) showDetails() {
+	obj := i.selectedObject()
+	if obj == nil {
+		return
+	}
+	marshaled, err := yaml.Marshal(obj)
+	if err != nil {
+		i.message = fmt.Sprintf("Error: failed marshaling %s %s: %s", inspect.KindOf(obj), obj.GetName(), err.Error())
+		return
+	}
+	i.showPager(fmt.Sprintf("%s %s/%s", inspect.KindOf(obj), obj.GetNamespace(), obj.GetName()), string(marshaled))
+}
+
+func (i *interactiveInspector) showEvents(ctx context.Context) {
+	obj := i.selectedObject()
+	if obj == nil {
+		return
+	}
+	events, err := i.collector.CollectObjectEvents(ctx, obj)
+	if err != nil {
+		i.message = "Error: " + err.Error()
+		return
+	}
+	if len(events) == 0 {
+		i.message = fmt.Sprintf("There are no events of %s %s", inspect.KindOf(obj), obj.GetName())
+		return
+	}
+
+	out := strings.Builder{}
+	for _, event := range events {
+		fmt.Fprintf(&out, "%s %s %s: %s\n", formatTime(event.LastTimestamp.Time), event.Type, event.Reason, event.Message)
+	}
+	i.showPager(fmt.Sprintf("Events of %s %s/%s", inspect.KindOf(obj), obj.GetNamespace(), obj.GetName()), out.String())
+}
+
+// showLogs shows the last lines of the containers of the pod that executes a container deployItem.
+func (i *interactiveInspector) showLogs(ctx context.Context) {
+	obj := i.selectedObject()
+	if obj == nil {
+		return
+	}
+	deployItem, ok := obj.(*lsv1alpha1.DeployItem)
+	if !ok || deployItem.Spec.Type != inspect.ContainerDeployItemType {
+		i.message = "Logs are only available for container deployItems, use 'landscaper-cli installations logs --deployer-logs' for other deployItems"
+		return
+	}
+	if i.offline {
+		i.message = "Logs are not available for dumps"
+		return
+	}
+
+	if i.hostClientset == nil {
+		hostConfig, _, err := util.BuildRestConfigFromConfigOrCurrentClusterContext(i.opts.kubeconfig)
+		if err != nil {
+			i.message = "Error: cannot build k8s config for the host cluster: " + err.Error()
+			return
+		}
+		i.hostClientset, err = kubernetes.NewForConfig(hostConfig)
+		if err != nil {
+			i.message = "Error: cannot build k8s clientset for the host cluster: " + err.Error()
+			return
+		}
+	}
+
+	logs := &logsOptions{
+		hostClientset: i.hostClientset,
+		tailLines:     interactiveLogTailLines,
+	}
+	sources, err := logs.getContainerLogSources(ctx, deployItem)
+	if err != nil {
+		i.message = "Error: " + err.Error()
+		return
+	}
+	if len(sources) == 0 {
+		i.message = fmt.Sprintf("No pod found for deployItem %s", deployItem.Name)
+		return
+	}
+
+	out := bytes.Buffer{}
+	for _, source := range sources {
+		if err := logs.streamLog(ctx, &out, source); err != nil {
+			fmt.Fprintf(&out, "- cannot get log of pod %s/%s container %s: %s\n", source.namespace, source.pod, source.container, err.Error())
+		}
+	}
+	i.showPager(fmt.Sprintf("Logs of DeployItem %s/%s", deployItem.Namespace, deployItem.Name), out.String())
+}
+
+// confirmOperation asks for confirmation before the operation annotation is added to the selected root installation.
+func (i *interactiveInspector) confirmOperation(ctx context.Context, name string, operation lsv1alpha1.Operation) {
+	obj := i.selectedObject()
+	if obj == nil {
+		return
+	}
+	installation, ok := obj.(*lsv1alpha1.Installation)
+	if !ok || !i.view.IsRoot() {
+		i.message = fmt.Sprintf("Only root installations can be annotated with %s", name)
+		return
+	}
+	if i.offline {
+		i.message = "Installations of dumps cannot be changed"
+		return
+	}
+
+	i.mode = interactiveConfirmMode
+	i.confirmPrompt = fmt.Sprintf("Add the %s annotation to installation %s/%s? [y/N]", name, installation.Namespace, installation.Name)
+	i.confirmedAction = func() (string, error) {
+		opts := &annotationOptions{
+			annotationKey:         lsv1alpha1.OperationAnnotation,
+			annotationValue:       string(operation),
+			rootInstallationsOnly: true,
+		}
+		if err := opts.annotate(ctx, i.k8sClient, client.ObjectKeyFromObject(installation)); err != nil {
+			return "", err
+		}
+		if err := i.refresh(ctx); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("The %s annotation was added to the installation", name), nil
+	}
+}
+
+func (i *interactiveInspector) screenSize(fd int) (int, int) {
+	width, height, err := term.GetSize(fd)
+	if err != nil || width <= 0 || height <= 0 {
+		return 120, 40
+	}
+	return width, height
+}
+
+// pageSize is the number of rows between the header and the footer.
+func (i *interactiveInspector) pageSize() int {
+	_, height := i.screenSize(int(os.Stdin.Fd()))
+	if height <= 4 {
+		return 1
+	}
+	return height - 4
+}
+
+func (i *interactiveInspector) draw(fd int) {
+	width, _ := i.screenSize(fd)
+	height := i.pageSize()
+
+	lines := []string{}
+	footer := []string{}
+	if i.mode == interactivePagerMode {
+		lines = append(lines, inspect.TruncateLine(i.pager.title+" - q: back", width), "")
+		pagerLines := i.pager.lines
+		for l := i.pager.offset; l < len(pagerLines) && l < i.pager.offset+height; l++ {
+			lines = append(lines, pagerLines[l])
+		}
+		footer = append(footer, fmt.Sprintf("lines %d-%d of %d", min(i.pager.offset+1, len(pagerLines)), min(i.pager.offset+height, len(pagerLines)), len(pagerLines)))
+	} else {
+		namespace := i.opts.namespace
+		if namespace == "*" {
+			namespace = "all namespaces"
+		}
+		lines = append(lines, inspect.TruncateLine(fmt.Sprintf("Installations in %s - ?: help, q: quit", namespace), width), "")
+		lines = append(lines, i.view.Render(width, height)...)
+
+		description := ""
+		if node := i.view.Selected(); node != nil {
+			description = strings.SplitN(node.Description, "\n", 2)[0]
+		}
+		footer = append(footer, inspect.TruncateLine(description, width))
+	}
+	for len(lines) < height+2 {
+		lines = append(lines, "")
+	}
+
+	status := i.message
+	if i.mode == interactiveConfirmMode {
+		status = i.confirmPrompt
+	}
+	footer = append(footer, inspect.TruncateLine(status, width))
+
+	// the terminal is in raw mode, so lines are terminated with carriage return and line feed
+	fmt.Fprint(i.out, clearScreen+strings.Join(append(lines, footer...), "\r\n"))
+}
+
+// wrapLines breaks lines that are longer than the screen width.
+func wrapLines(lines []string, width int) []string {
+	wrapped := []string{}
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		runes := []rune(line)
+		for width > 0 && len(runes) > width {
+			wrapped = append(wrapped, string(runes[:width]))
+			runes = runes[width:]
+		}
+		wrapped = append(wrapped, string(runes))
+	}
+	return wrapped
+}
+
+// readKeys reads key presses from the terminal and sends their names, e.g. "up" or "q".
+func readKeys(in io.Reader) <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 64)
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+			for _, key := range parseKeys(buf[:n]) {
+				keys <- key
+			}
+		}
+	}()
+	return keys
+}
+
+func parseKeys(input []byte) []string {
+	keys := []string{}
+	s := string(input)
+	for len(s) > 0 {
+		if s[0] == 0x1b {
+			key, length := parseEscapeSequence(s)
+			if key != "" {
+				keys = append(keys, key)
+			}
+			s = s[length:]
+			continue
+		}
+
+		switch s[0] {
+		case '\r', '\n':
+			keys = append(keys, "enter")
+		case 0x03:
+			keys = append(keys, "ctrl+c")
+		default:
+			r, size := utf8.DecodeRuneInString(s)
+			keys = append(keys, string(r))
+			s = s[size:]
+			continue
+		}
+		s = s[1:]
+	}
+	return keys
+}
+
+// parseEscapeSequence returns the key of the escape sequence at the beginning of s and its length. Unknown control
+// sequences are skipped, a single escape character is the escape key.
+func parseEscapeSequence(s string) (string, int) {
+	for sequence, key := range escapeSequences {
+		if strings.HasPrefix(s, sequence) {
+			return key, len(sequence)
+		}
+	}
+	if strings.HasPrefix(s, "\x1b[") {
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return "", i + 1
+			}
+		}
+		return "", len(s)
+	}
+	return "esc", 1
+}
@@ -0,0 +1,30 @@
+package installations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/stretchr/testify/assert"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+)
+
+func TestInteractiveRefreshOnlyFailed(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./inspect/testdata")
+	assert.NoError(t, err)
+
+	// the root installation inttest/my-aggregation contains no failed object
+	i := &interactiveInspector{
+		opts:      &statusOptions{namespace: "inttest", showOnlyFailed: true},
+		k8sClient: fakeClient,
+		collector: &inspect.Collector{K8sClient: fakeClient},
+		filter:    &inspect.TreeFilter{},
+	}
+	assert.NoError(t, i.refresh(context.TODO()))
+	assert.Empty(t, i.view.Rows())
+
+	i.opts.namespace = "default"
+	assert.NoError(t, i.refresh(context.TODO()))
+	assert.NotEmpty(t, i.view.Rows())
+}
@@ -31,9 +31,21 @@ func NewInstallationsCommand(ctx context.Context) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewInspectCommand(ctx))
+	cmd.AddCommand(NewCreateCommand(ctx))
+	cmd.AddCommand(NewDeleteCommand(ctx))
 	cmd.AddCommand(NewForceDeleteCommand(ctx))
 	cmd.AddCommand(NewReconcileCommand(ctx))
 	cmd.AddCommand(NewInterruptCommand(ctx))
+	cmd.AddCommand(NewOperationCommand(ctx))
+	cmd.AddCommand(NewWatchCommand(ctx))
+	cmd.AddCommand(NewWaitCommand(ctx))
+	cmd.AddCommand(NewExplainFailureCommand(ctx))
+	cmd.AddCommand(NewLogsCommand(ctx))
+	cmd.AddCommand(NewImportsCommand(ctx))
+	cmd.AddCommand(NewExportsCommand(ctx))
+	cmd.AddCommand(NewDepsCommand(ctx))
+	cmd.AddCommand(NewOwnerCommand(ctx))
+	cmd.AddCommand(NewOrphansCommand(ctx))
 
 	return cmd
 }
@@ -2,38 +2,62 @@ package installations
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/gardener/landscaper/apis/core/v1alpha1"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/util"
 )
 
+type interruptOptions struct {
+	annotationOptions
+
+	wait     bool
+	timeout  time.Duration
+	interval time.Duration
+
+	deleteStuckPods   bool
+	hostKubeconfig    string
+	deployerNamespace string
+}
+
 func NewInterruptCommand(ctx context.Context) *cobra.Command {
-	opts := &annotationOptions{
-		annotationKey:   v1alpha1.OperationAnnotation,
-		annotationValue: string(v1alpha1.InterruptOperation),
+	opts := &interruptOptions{
+		annotationOptions: annotationOptions{
+			annotationKey:   v1alpha1.OperationAnnotation,
+			annotationValue: string(v1alpha1.InterruptOperation),
+		},
 	}
 
 	cmd := &cobra.Command{
-		Use:     "interrupt [installation-name] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Use:     "interrupt [installation-name] [--wait] [--delete-stuck-pods] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
 		Args:    cobra.MaximumNArgs(1),
-		Example: "landscaper-cli installations interrupt MY_INSTALLATION --namespace MY_NAMESPACE",
+		Example: "landscaper-cli installations interrupt MY_INSTALLATION --namespace MY_NAMESPACE --wait --delete-stuck-pods",
 		Short: "Interrupts the processing of an installations and its subobjects. All of these objects with an " +
 			"unfinished phase (i.e. a phase which is neither 'Succeeded' nor 'Failed' nor 'DeleteFailed') " +
 			"are changed to phase 'Failed'. Note that the command affects only the status of Landscaper objects, " +
-			"but does not interrupt a running installation process, for example a helm deployment.",
+			"but does not interrupt a running installation process, for example a helm deployment. With " +
+			"--delete-stuck-pods, the pods of container deployItems are deleted once the interrupt has been processed, and with --wait, the command " +
+			"waits until all objects are in a final phase.",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := opts.validateArgs(args); err != nil {
 				cmd.PrintErr(err.Error())
 				os.Exit(1)
 			}
 
-			if err := opts.run(ctx); err != nil {
+			if err := opts.run(ctx, cmd); err != nil {
 				cmd.PrintErr(err.Error())
 				os.Exit(1)
 			}
-
-			cmd.Println("The interrupt annotation was added to the installation")
 		},
 	}
 
@@ -43,3 +67,206 @@ func NewInterruptCommand(ctx context.Context) *cobra.Command {
 
 	return cmd
 }
+
+func (o *interruptOptions) run(ctx context.Context, cmd *cobra.Command) error {
+	if !o.wait && !o.deleteStuckPods {
+		if err := o.annotationOptions.run(ctx); err != nil {
+			return err
+		}
+		cmd.Println("The interrupt annotation was added to the installation")
+		return nil
+	}
+
+	kubeClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	if o.installationName == "" {
+		return fmt.Errorf("installationName was not defined.")
+	}
+
+	// the tree before the interrupt is needed to report the objects changed to phase Failed and to find the stuck pods
+	collector := inspect.Collector{
+		K8sClient: kubeClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+	if err != nil {
+		return fmt.Errorf("cannot collect installation: %w", err)
+	}
+	before := installationTrees[0]
+
+	if err := o.annotate(ctx, kubeClient, client.ObjectKeyFromObject(before.Installation)); err != nil {
+		return err
+	}
+	cmd.Println("The interrupt annotation was added to the installation")
+
+	if o.deleteStuckPods {
+		deployItems := unfinishedContainerDeployItems(before)
+		if err := o.waitUntilDeployItemsFinal(ctx, cmd, &collector, deployItems); err != nil {
+			return err
+		}
+		if err := o.deletePodsOfDeployItems(ctx, cmd, deployItems); err != nil {
+			return err
+		}
+	}
+
+	if !o.wait {
+		return nil
+	}
+	return o.waitUntilSettled(ctx, cmd, &collector, before)
+}
+
+// unfinishedContainerDeployItems returns the container deployItems which were in an unfinished phase before the
+// interrupt. The interrupt does not stop their pods.
+func unfinishedContainerDeployItems(installationTree *inspect.InstallationTree) []*v1alpha1.DeployItem {
+	deployItems := []*v1alpha1.DeployItem{}
+	for _, deployItem := range installationTree.DeployItems() {
+		if deployItem.Spec.Type == inspect.ContainerDeployItemType && !deployItem.Status.Phase.IsFinal() {
+			deployItems = append(deployItems, deployItem)
+		}
+	}
+	return deployItems
+}
+
+// waitUntilDeployItemsFinal polls the deployItems until the landscaper has processed the interrupt and they are in a
+// final phase or gone, so that their pods are not deleted while the deployer still handles them.
+func (o *interruptOptions) waitUntilDeployItemsFinal(ctx context.Context, cmd *cobra.Command, collector *inspect.Collector, deployItems []*v1alpha1.DeployItem) error {
+	if len(deployItems) == 0 {
+		return nil
+	}
+
+	final := false
+	_ = wait.PollUntilContextTimeout(ctx, o.interval, o.timeout, true, func(ctx context.Context) (done bool, err error) {
+		installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+		if err != nil {
+			cmd.Printf("- cannot collect installation %s - will retry\n", o.installationName)
+			return false, nil
+		}
+		phases := map[string]v1alpha1.DeployItemPhase{}
+		for _, deployItem := range installationTrees[0].DeployItems() {
+			phases[deployItem.Namespace+"/"+deployItem.Name] = deployItem.Status.Phase
+		}
+		for _, deployItem := range deployItems {
+			if phase, ok := phases[deployItem.Namespace+"/"+deployItem.Name]; ok && !phase.IsFinal() {
+				return false, nil
+			}
+		}
+		final = true
+		return true, nil
+	})
+
+	if !final {
+		return fmt.Errorf("timeout: the container deployItems of installation %s have not reached a final phase within %s, no pods were deleted", o.installationName, o.timeout)
+	}
+	return nil
+}
+
+// deletePodsOfDeployItems deletes the pods of the container deployItems. The pods are searched in the namespace of the
+// container deployer if it is given, and in all namespaces otherwise.
+func (o *interruptOptions) deletePodsOfDeployItems(ctx context.Context, cmd *cobra.Command, deployItems []*v1alpha1.DeployItem) error {
+	if len(deployItems) == 0 {
+		return nil
+	}
+
+	hostKubeconfig := o.hostKubeconfig
+	if hostKubeconfig == "" {
+		hostKubeconfig = o.kubeconfig
+	}
+	hostConfig, _, err := util.BuildRestConfigFromConfigOrCurrentClusterContext(hostKubeconfig)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s config for the host cluster: %w", err)
+	}
+	hostClientset, err := kubernetes.NewForConfig(hostConfig)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s clientset for the host cluster: %w", err)
+	}
+
+	podNamespace := metav1.NamespaceAll
+	if o.deployerNamespace != "" {
+		podNamespace = o.deployerNamespace
+	}
+	for _, deployItem := range deployItems {
+		podList, err := hostClientset.CoreV1().Pods(podNamespace).List(ctx, metav1.ListOptions{LabelSelector: containerPodSelector(deployItem).String()})
+		if err != nil {
+			return fmt.Errorf("cannot list pods of deployItem %s: %w", deployItem.Name, err)
+		}
+		for _, pod := range podList.Items {
+			if err := hostClientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+				return fmt.Errorf("cannot delete pod %s/%s of deployItem %s: %w", pod.Namespace, pod.Name, deployItem.Name, err)
+			}
+			cmd.Printf("- deleted pod %s/%s of deployItem %s\n", pod.Namespace, pod.Name, deployItem.Name)
+		}
+	}
+	return nil
+}
+
+// waitUntilSettled polls the tree until the landscaper has processed the interrupt annotation and all objects are in a
+// final phase. Afterwards, the objects which were changed to a failed phase are printed.
+func (o *interruptOptions) waitUntilSettled(ctx context.Context, cmd *cobra.Command, collector *inspect.Collector, before *inspect.InstallationTree) error {
+	var after *inspect.InstallationTree
+	settled := false
+
+	_ = wait.PollUntilContextTimeout(ctx, o.interval, o.timeout, true, func(ctx context.Context) (done bool, err error) {
+		installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+		if err != nil {
+			cmd.Printf("- cannot collect installation %s - will retry\n", o.installationName)
+			return false, nil
+		}
+		after = installationTrees[0]
+		settled = !metav1.HasAnnotation(after.Installation.ObjectMeta, v1alpha1.OperationAnnotation) && after.IsSettled()
+		return settled, nil
+	})
+
+	if !settled {
+		return fmt.Errorf("timeout: installation %s has not settled within %s", o.installationName, o.timeout)
+	}
+
+	changed := after.ChangedToFailed(before)
+	if len(changed) == 0 {
+		cmd.Println("All objects are in a final phase, no object was changed to a failed phase")
+		return nil
+	}
+	cmd.Println("All objects are in a final phase. The following objects were changed to a failed phase:")
+	for _, obj := range changed {
+		phase := obj.Phase
+		if phase == "" {
+			phase = "none"
+		}
+		cmd.Printf("- %s %s/%s (previous phase %s)\n", obj.Kind, obj.Namespace, obj.Name, phase)
+	}
+	return nil
+}
+
+func (o *interruptOptions) validateArgs(args []string) error {
+	if err := o.annotationOptions.validateArgs(args); err != nil {
+		return err
+	}
+	if o.wait || o.deleteStuckPods {
+		if o.timeout <= 0 {
+			return fmt.Errorf("the timeout must be positive")
+		}
+		if o.interval <= 0 {
+			return fmt.Errorf("the interval must be positive")
+		}
+	}
+	return nil
+}
+
+func (o *interruptOptions) AddFlags(fs *pflag.FlagSet) {
+	o.annotationOptions.AddFlags(fs)
+	fs.BoolVar(&o.wait, "wait", false, "wait until the interrupt has been processed and all objects of the installation are in a final phase, and print the objects which were changed to a failed phase.")
+	fs.DurationVar(&o.timeout, "timeout", 5*time.Minute, "together with --wait or --delete-stuck-pods, the maximal time to wait.")
+	fs.DurationVar(&o.interval, "interval", 5*time.Second, "together with --wait or --delete-stuck-pods, the time between two checks of the installation.")
+	fs.BoolVar(&o.deleteStuckPods, "delete-stuck-pods", false, "delete the pods of the container deployItems which are in an unfinished phase, so that running container jobs are stopped. The pods are deleted after the interrupt has been processed and the deployItems are in a final phase.")
+	fs.StringVar(&o.hostKubeconfig, "host-kubeconfig", "", "path to the kubeconfig for the cluster in which the container deployer pods run. Only required for --delete-stuck-pods if it is not the cluster of the installation.")
+	fs.StringVar(&o.deployerNamespace, "deployer-namespace", "", "namespace in which the container deployer runs the pods of the deployItems. Used by --delete-stuck-pods, which searches all namespaces if it is not set.")
+}
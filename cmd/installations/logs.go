@@ -0,0 +1,372 @@
+package installations
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/apis/deployer/container"
+	containerv1alpha1 "github.com/gardener/landscaper/apis/deployer/container/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+type logsOptions struct {
+	kubeconfig          string
+	hostKubeconfig      string
+	installationName    string
+	namespace           string
+	deployItemName      string
+	follow              bool
+	tailLines           int64
+	deployerLogs        bool
+	landscaperNamespace string
+	deployerSelector    string
+
+	hostClientset kubernetes.Interface
+	outputLock    sync.Mutex
+}
+
+// logSource is a container whose log is printed. If filters are given, only lines which contain one of them are printed.
+type logSource struct {
+	namespace string
+	pod       string
+	container string
+	filters   []string
+}
+
+func NewLogsCommand(ctx context.Context) *cobra.Command {
+	opts := &logsOptions{}
+	cmd := &cobra.Command{
+		Use:     "logs [installation-name] [--deployitem deployitem-name] [--follow] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.ExactArgs(1),
+		Example: "landscaper-cli installations logs MY_INSTALLATION --namespace MY_NAMESPACE --deployitem MY_DEPLOYITEM --follow",
+		Short: "Prints the logs of the pods that execute the container deployItems of an installation and its " +
+			"subinstallations, i.e. the logs of the init, wait and main containers. Optionally, the log lines of the " +
+			"responsible deployer pods in the Landscaper namespace that mention a deployItem are printed as well.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd, logger.Log); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *logsOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	hostKubeconfig := o.hostKubeconfig
+	if hostKubeconfig == "" {
+		hostKubeconfig = o.kubeconfig
+	}
+	hostConfig, _, err := util.BuildRestConfigFromConfigOrCurrentClusterContext(hostKubeconfig)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s config for the host cluster: %w", err)
+	}
+	o.hostClientset, err = kubernetes.NewForConfig(hostConfig)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s clientset for the host cluster: %w", err)
+	}
+
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+	if err != nil {
+		return fmt.Errorf("cannot collect installation: %w", err)
+	}
+
+	deployItems := o.selectDeployItems(installationTrees[0].DeployItems())
+	if len(deployItems) == 0 {
+		if o.deployItemName != "" {
+			return fmt.Errorf("installation %s has no deployItem %s", o.installationName, o.deployItemName)
+		}
+		return fmt.Errorf("installation %s has no deployItems", o.installationName)
+	}
+
+	sources := []logSource{}
+	for _, deployItem := range deployItems {
+		if deployItem.Spec.Type == inspect.ContainerDeployItemType {
+			containerSources, err := o.getContainerLogSources(ctx, deployItem)
+			if err != nil {
+				return err
+			}
+			if len(containerSources) == 0 {
+				cmd.PrintErrf("- no pod found for deployItem %s\n", deployItem.Name)
+			}
+			sources = append(sources, containerSources...)
+		} else if !o.deployerLogs {
+			cmd.PrintErrf("- deployItem %s of type %s is not executed in a pod, use --deployer-logs to print the logs of its deployer\n",
+				deployItem.Name, deployItem.Spec.Type)
+		}
+
+		if o.deployerLogs {
+			deployerSources, err := o.getDeployerLogSources(ctx, deployItem)
+			if err != nil {
+				return err
+			}
+			if len(deployerSources) == 0 {
+				cmd.PrintErrf("- no deployer pod found for deployItem %s in namespace %s\n", deployItem.Name, o.landscaperNamespace)
+			}
+			sources = append(sources, deployerSources...)
+		}
+	}
+
+	return o.streamLogs(ctx, cmd, mergeLogSources(sources))
+}
+
+// mergeLogSources merges sources of the same container, e.g. of a deployer pod which is responsible for several
+// deployItems, so that every container log is only streamed once.
+func mergeLogSources(sources []logSource) []logSource {
+	merged := []logSource{}
+	indexes := map[string]int{}
+	for _, source := range sources {
+		key := fmt.Sprintf("%s/%s/%s", source.namespace, source.pod, source.container)
+		i, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(merged)
+			merged = append(merged, source)
+			continue
+		}
+		if len(merged[i].filters) > 0 && len(source.filters) > 0 {
+			merged[i].filters = append(merged[i].filters, source.filters...)
+		} else {
+			merged[i].filters = nil
+		}
+	}
+	return merged
+}
+
+// selectDeployItems returns all deployItems if no deployItem name is given. Otherwise, it returns the deployItem with
+// the given name or with the given name in the execution.
+func (o *logsOptions) selectDeployItems(deployItems []*lsv1alpha1.DeployItem) []*lsv1alpha1.DeployItem {
+	if o.deployItemName == "" {
+		return deployItems
+	}
+
+	selected := []*lsv1alpha1.DeployItem{}
+	for _, deployItem := range deployItems {
+		if deployItem.Name == o.deployItemName || deployItem.Labels[lsv1alpha1.ExecutionManagedNameLabel] == o.deployItemName {
+			selected = append(selected, deployItem)
+		}
+	}
+	return selected
+}
+
+// getContainerLogSources returns the init, wait and main container of the pod that executes a container deployItem.
+// The pod is identified by the labels of the container deployer and, if available, by the name in the provider status.
+func (o *logsOptions) getContainerLogSources(ctx context.Context, deployItem *lsv1alpha1.DeployItem) ([]logSource, error) {
+	podName := ""
+	if deployItem.Status.ProviderStatus != nil {
+		providerStatus := &containerv1alpha1.ProviderStatus{}
+		if err := json.Unmarshal(deployItem.Status.ProviderStatus.Raw, providerStatus); err == nil && providerStatus.PodStatus != nil {
+			podName = providerStatus.PodStatus.PodName
+		}
+	}
+
+	podList, err := o.hostClientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: containerPodSelector(deployItem).String()})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list pods of deployItem %s: %w", deployItem.Name, err)
+	}
+
+	pod := selectPod(podList.Items, podName)
+	if pod == nil {
+		return nil, nil
+	}
+
+	sources := []logSource{}
+	for _, containerName := range []string{container.InitContainerName, container.WaitContainerName, container.MainContainerName} {
+		if hasContainer(pod, containerName) {
+			sources = append(sources, logSource{namespace: pod.Namespace, pod: pod.Name, container: containerName})
+		}
+	}
+	return sources, nil
+}
+
+// containerPodSelector selects the pods which the container deployer has created for the deployItem.
+func containerPodSelector(deployItem *lsv1alpha1.DeployItem) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		container.ContainerDeployerDeployItemNameLabel:      deployItem.Name,
+		container.ContainerDeployerDeployItemNamespaceLabel: deployItem.Namespace,
+	})
+}
+
+// selectPod returns the pod with the given name, or the newest pod if no pod has this name.
+func selectPod(pods []corev1.Pod, podName string) *corev1.Pod {
+	if len(pods) == 0 {
+		return nil
+	}
+	for i := range pods {
+		if pods[i].Name == podName {
+			return &pods[i]
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+	return &pods[0]
+}
+
+func hasContainer(pod *corev1.Pod, containerName string) bool {
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if c.Name == containerName {
+			return true
+		}
+	}
+	return false
+}
+
+// getDeployerLogSources returns the containers of the deployer pods in the Landscaper namespace which are responsible
+// for the deployItem. Only log lines which mention the deployItem are printed.
+func (o *logsOptions) getDeployerLogSources(ctx context.Context, deployItem *lsv1alpha1.DeployItem) ([]logSource, error) {
+	podList, err := o.hostClientset.CoreV1().Pods(o.landscaperNamespace).List(ctx, metav1.ListOptions{LabelSelector: o.deployerSelector})
+	if err != nil {
+		return nil, fmt.Errorf("cannot list pods in namespace %s: %w", o.landscaperNamespace, err)
+	}
+
+	deployerName := deployItem.Status.Deployer.Name
+	if deployerName == "" {
+		// e.g. "landscaper.gardener.cloud/kubernetes-manifest" is handled by the manifest deployer
+		deployerName = strings.TrimPrefix(string(deployItem.Spec.Type), "landscaper.gardener.cloud/")
+		deployerName = strings.TrimPrefix(deployerName, "kubernetes-")
+	}
+
+	sources := []logSource{}
+	for _, pod := range podList.Items {
+		if o.deployerSelector == "" && !strings.Contains(pod.Name, deployerName) {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			sources = append(sources, logSource{namespace: pod.Namespace, pod: pod.Name, container: c.Name, filters: []string{deployItem.Name}})
+		}
+	}
+	return sources, nil
+}
+
+// streamLogs prints the logs of all sources concurrently. Every line is prefixed with the pod and container name. The
+// logs of the other sources are still printed if a source fails, but an error is returned afterwards.
+func (o *logsOptions) streamLogs(ctx context.Context, cmd *cobra.Command, sources []logSource) error {
+	wg := sync.WaitGroup{}
+	failed := 0
+	for _, source := range sources {
+		wg.Add(1)
+		go func(source logSource) {
+			defer wg.Done()
+			if err := o.streamLog(ctx, cmd.OutOrStdout(), source); err != nil {
+				o.outputLock.Lock()
+				failed++
+				cmd.PrintErrf("- cannot get log of pod %s/%s container %s: %s\n", source.namespace, source.pod, source.container, err.Error())
+				o.outputLock.Unlock()
+			}
+		}(source)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return fmt.Errorf("cannot get the logs of %d of %d containers", failed, len(sources))
+	}
+	return nil
+}
+
+func (o *logsOptions) streamLog(ctx context.Context, out io.Writer, source logSource) error {
+	logOptions := &corev1.PodLogOptions{
+		Container: source.container,
+		Follow:    o.follow,
+	}
+	if o.tailLines >= 0 {
+		logOptions.TailLines = &o.tailLines
+	}
+
+	stream, err := o.hostClientset.CoreV1().Pods(source.namespace).GetLogs(source.pod, logOptions).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	prefix := fmt.Sprintf("[%s/%s] ", source.pod, source.container)
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !matchesAnyFilter(line, source.filters) {
+			continue
+		}
+		o.outputLock.Lock()
+		fmt.Fprintf(out, "%s%s\n", prefix, line)
+		o.outputLock.Unlock()
+	}
+	return scanner.Err()
+}
+
+func matchesAnyFilter(line string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	for _, filter := range filters {
+		if strings.Contains(line, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *logsOptions) validateArgs(args []string) error {
+	o.installationName = args[0]
+	if o.deployerSelector != "" {
+		if _, err := labels.Parse(o.deployerSelector); err != nil {
+			return fmt.Errorf("invalid deployer selector: %w", err)
+		}
+	}
+	return nil
+}
+
+func (o *logsOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVar(&o.hostKubeconfig, "host-kubeconfig", "", "path to the kubeconfig for the cluster in which the deployers and their pods run. Only required if it is not the cluster of the installation.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+	fs.StringVar(&o.deployItemName, "deployitem", "", "name of the deployItem, either the name of the object or its name in the execution. By default, the logs of all deployItems are printed.")
+	fs.BoolVarP(&o.follow, "follow", "f", false, "specify if the logs should be streamed.")
+	fs.Int64Var(&o.tailLines, "tail", -1, "lines of recent log to display. By default, all lines are displayed.")
+	fs.BoolVar(&o.deployerLogs, "deployer-logs", false, "also print the log lines of the responsible deployer pods which mention the deployItem.")
+	fs.StringVar(&o.landscaperNamespace, "landscaper-namespace", "landscaper", "namespace in which the deployer pods run.")
+	fs.StringVar(&o.deployerSelector, "deployer-selector", "", "label selector for the deployer pods. By default, the pods whose name contains the name of the responsible deployer are used.")
+}
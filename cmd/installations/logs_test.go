@@ -0,0 +1,69 @@
+package installations
+
+import (
+	"testing"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMergeLogSources(t *testing.T) {
+	merged := mergeLogSources([]logSource{
+		{namespace: "landscaper", pod: "helm-deployer", container: "deployer", filters: []string{"ingress"}},
+		{namespace: "ls-system", pod: "pod-a", container: "main"},
+		{namespace: "landscaper", pod: "helm-deployer", container: "deployer", filters: []string{"server"}},
+		{namespace: "landscaper", pod: "manifest-deployer", container: "deployer", filters: []string{"config"}},
+		{namespace: "landscaper", pod: "manifest-deployer", container: "deployer"},
+	})
+
+	assert.Equal(t, []logSource{
+		{namespace: "landscaper", pod: "helm-deployer", container: "deployer", filters: []string{"ingress", "server"}},
+		{namespace: "ls-system", pod: "pod-a", container: "main"},
+		// a source without filters prints all lines
+		{namespace: "landscaper", pod: "manifest-deployer", container: "deployer"},
+	}, merged)
+}
+
+func TestMatchesAnyFilter(t *testing.T) {
+	assert.True(t, matchesAnyFilter("reconcile deployitem ingress", nil))
+	assert.True(t, matchesAnyFilter("reconcile deployitem ingress", []string{"server", "ingress"}))
+	assert.False(t, matchesAnyFilter("reconcile deployitem config", []string{"server", "ingress"}))
+}
+
+func TestSelectPod(t *testing.T) {
+	now := time.Now()
+	pod := func(name string, created time.Time) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+	}
+
+	assert.Nil(t, selectPod(nil, "pod-a"))
+	assert.Equal(t, "pod-a", selectPod([]corev1.Pod{pod("pod-b", now), pod("pod-a", now.Add(-time.Hour))}, "pod-a").Name)
+	// without a matching name, the newest pod is selected
+	assert.Equal(t, "pod-c", selectPod([]corev1.Pod{pod("pod-b", now.Add(-time.Hour)), pod("pod-c", now)}, "pod-a").Name)
+	assert.Equal(t, "pod-c", selectPod([]corev1.Pod{pod("pod-c", now), pod("pod-b", now.Add(-time.Hour))}, "").Name)
+}
+
+func TestSelectDeployItems(t *testing.T) {
+	deployItem := func(name, nameInExecution string) *lsv1alpha1.DeployItem {
+		return &lsv1alpha1.DeployItem{ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{lsv1alpha1.ExecutionManagedNameLabel: nameInExecution},
+		}}
+	}
+	deployItems := []*lsv1alpha1.DeployItem{deployItem("ingress-deploy-kptjl", "deploy"), deployItem("server-deploy-7mhc2", "server")}
+
+	o := &logsOptions{}
+	assert.Equal(t, deployItems, o.selectDeployItems(deployItems))
+
+	o.deployItemName = "ingress-deploy-kptjl"
+	assert.Equal(t, deployItems[:1], o.selectDeployItems(deployItems))
+
+	o.deployItemName = "server"
+	assert.Equal(t, deployItems[1:], o.selectDeployItems(deployItems))
+
+	o.deployItemName = "unknown"
+	assert.Empty(t, o.selectDeployItems(deployItems))
+}
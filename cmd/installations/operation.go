@@ -0,0 +1,43 @@
+package installations
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/pkg/landscaper/installations"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/landscapercli/pkg/operations"
+)
+
+func NewOperationCommand(ctx context.Context) *cobra.Command {
+	opts := operations.NewCommandOptions("installation", operations.InstallationOperations)
+	cmd := &cobra.Command{
+		Use:     "operation [installation-name] --op operation [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.ExactArgs(1),
+		Example: "landscaper-cli installations operation MY_INSTALLATION --op reconcile-if-changed --namespace MY_NAMESPACE",
+		Short:   "Triggers a landscaper operation on an installation by setting the corresponding annotation.",
+		Long: "Triggers a landscaper operation on an installation by setting the corresponding annotation. Who " +
+			"triggered the operation is recorded in the annotation " + operations.TriggeredByAnnotation + ". The " +
+			"following operations are supported:\n\n" + operations.Describe(operations.InstallationOperations),
+		Run: opts.Run(ctx, scheme, func() client.Object { return &lsv1alpha1.Installation{} }, applyInstallationOperation),
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func applyInstallationOperation(obj client.Object, op *operations.Operation) error {
+	installation := obj.(*lsv1alpha1.Installation)
+	if op.RootOnly && !installations.IsRootInstallation(installation) {
+		return fmt.Errorf("the %s operation is only supported for root installations", op.Name)
+	}
+	op.Annotate(&installation.ObjectMeta)
+	return nil
+}
@@ -0,0 +1,184 @@
+package installations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+type orphansOptions struct {
+	kubeconfig    string
+	namespace     string
+	allNamespaces bool
+	omode         string
+
+	delete bool
+	dryRun bool
+}
+
+// orphansReport is printed with --delete --dry-run.
+type orphansReport struct {
+	DryRun  bool              `json:"dryRun"`
+	Orphans []*inspect.Orphan `json:"orphans"`
+}
+
+func NewOrphansCommand(ctx context.Context) *cobra.Command {
+	opts := &orphansOptions{}
+	cmd := &cobra.Command{
+		Use:     "orphans [--namespace namespace] [--all-namespaces] [--delete [--dry-run]] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.NoArgs,
+		Example: "landscaper-cli installations orphans --namespace MY_NAMESPACE --delete --dry-run",
+		Short: "Lists executions without owning installation, deployItems whose execution is missing or orphaned, and " +
+			"DataObjects whose context or source does not exist anymore, e.g. after a partial force-delete. " +
+			"With --delete, the orphaned objects are deleted and their finalizers are removed.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd, logger.Log); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *orphansOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if o.allNamespaces {
+		o.namespace = "*"
+	}
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+		Progress:  cmd.ErrOrStderr(),
+	}
+	orphans, err := collector.CollectOrphans(ctx, o.namespace)
+	if err != nil {
+		return fmt.Errorf("cannot collect orphans: %w", err)
+	}
+
+	if o.dryRun {
+		marshaledReport, err := yaml.Marshal(&orphansReport{DryRun: true, Orphans: orphans})
+		if err != nil {
+			return fmt.Errorf("failed marshaling report to yaml: %w", err)
+		}
+		cmd.Print(string(marshaledReport))
+		return nil
+	}
+
+	if o.delete {
+		// deployItems are deleted before their executions, as in force-delete
+		fd := &forceDeleteOptions{k8sClient: k8sClient}
+		deleted := 0
+		for _, orphan := range orphans {
+			objectType := strings.ToLower(orphan.Kind[:1]) + orphan.Kind[1:]
+			ownerExists, err := collector.OwnerExists(ctx, orphan)
+			if err != nil {
+				return err
+			}
+			if ownerExists {
+				cmd.Printf("- skipped %s %s/%s, its owner exists again\n", objectType, orphan.Namespace, orphan.Name)
+				continue
+			}
+			messages, err := fd.deleteObject(ctx, orphan.Object, objectType)
+			if err != nil {
+				return err
+			}
+			finalizerMessages, err := fd.removeFinalizer(ctx, orphan.Object, objectType)
+			for _, message := range append(messages, finalizerMessages...) {
+				cmd.Println(message)
+			}
+			if err != nil {
+				return err
+			}
+			deleted++
+		}
+		cmd.Printf("%d orphaned objects deleted\n", deleted)
+		return nil
+	}
+
+	switch o.omode {
+	case OUTPUT_YAML:
+		marshaledOrphans, err := yaml.Marshal(orphans)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to yaml: %w", err)
+		}
+		cmd.Print(string(marshaledOrphans))
+	case OUTPUT_JSON:
+		marshaledOrphans, err := json.Marshal(orphans)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to json: %w", err)
+		}
+		cmd.Print(string(marshaledOrphans))
+	default:
+		if len(orphans) == 0 {
+			cmd.Println("No orphaned objects found")
+			return nil
+		}
+		out := strings.Builder{}
+		w := tabwriter.NewWriter(&out, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "KIND\tNAMESPACE\tNAME\tREASON")
+		for _, orphan := range orphans {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", orphan.Kind, orphan.Namespace, orphan.Name, orphan.Reason)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		cmd.Print(out.String())
+	}
+
+	return nil
+}
+
+func (o *orphansOptions) validateArgs(args []string) error {
+	if o.dryRun && !o.delete {
+		return fmt.Errorf("the --dry-run option can only be used together with --delete")
+	}
+
+	switch o.omode {
+	case inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid option for '--output'/'-o' flag: %q", o.omode)
+	}
+}
+
+func (o *orphansOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace in which orphaned objects are searched. Required if --kubeconfig is used.")
+	fs.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "if present, searches orphaned objects across all namespaces. Any given namespace will be ignored.")
+	fs.BoolVar(&o.delete, "delete", false, "delete the orphaned objects and remove their finalizers. Concerning the deployed software no guarantees could be given if it is uninstalled or not.")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "together with --delete, print a yaml report of the objects which would be deleted instead of deleting them.")
+	fs.StringVarP(&o.omode, "output", "o", inspect.OutputTable, fmt.Sprintf("how the list of orphaned objects is formatted. Valid values are %s, %s, and %s.", inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON))
+}
@@ -0,0 +1,228 @@
+package installations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+type ownerOptions struct {
+	kubeconfig          string
+	landscaperNamespace string
+	omode               string
+
+	kind             string
+	name             string
+	namespace        string
+	targetKubeconfig string
+}
+
+func NewOwnerCommand(ctx context.Context) *cobra.Command {
+	opts := &ownerOptions{}
+	cmd := &cobra.Command{
+		Use:     "owner --kind kind --name name [--namespace namespace] [--target-kubeconfig target-kubeconfig.yaml] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.NoArgs,
+		Example: "landscaper-cli installations owner --kind Deployment --name my-deployment --namespace my-namespace --target-kubeconfig workload-cluster.yaml",
+		Short: "Finds the installation which deployed a kubernetes object in a target cluster. The deployItems in the " +
+			"landscaper cluster are searched for the object in their managed resources and, if the object is read from " +
+			"the target cluster, for its helm release. For each matching deployItem, the chain of its execution and " +
+			"installations up to the root installation is printed.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd, logger.Log); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *ownerOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
+	k8sClient, _, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	// managed resources are identified by kind without api group
+	kind, _, _ := strings.Cut(o.kind, ".")
+	obj := inspect.OwnedObject{
+		Kind:      kind,
+		Name:      o.name,
+		Namespace: o.namespace,
+	}
+	if o.targetKubeconfig != "" {
+		if err := o.readTargetObject(ctx, &obj); err != nil {
+			return err
+		}
+	}
+
+	landscaperNamespace := o.landscaperNamespace
+	if landscaperNamespace == "" {
+		landscaperNamespace = "*"
+	}
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+		Progress:  cmd.ErrOrStderr(),
+	}
+	owners, err := collector.FindOwners(ctx, landscaperNamespace, obj)
+	if err != nil {
+		return fmt.Errorf("cannot find owners: %w", err)
+	}
+
+	switch o.omode {
+	case OUTPUT_YAML:
+		marshaledOwners, err := yaml.Marshal(owners)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to yaml: %w", err)
+		}
+		cmd.Print(string(marshaledOwners))
+	case OUTPUT_JSON:
+		marshaledOwners, err := json.Marshal(owners)
+		if err != nil {
+			return fmt.Errorf("failed marshaling output to json: %w", err)
+		}
+		cmd.Print(string(marshaledOwners))
+	default:
+		if len(owners) == 0 {
+			return fmt.Errorf("no deployitem manages %s", formatOwnedObject(obj))
+		}
+		output, err := formatOwners(obj, owners)
+		if err != nil {
+			return err
+		}
+		cmd.Print(output)
+	}
+
+	return nil
+}
+
+// readTargetObject reads the object from the target cluster to verify that it exists, to complete its kind and to
+// determine the helm release it belongs to. The kind may be qualified with the api group, e.g. "Deployment.apps".
+func (o *ownerOptions) readTargetObject(ctx context.Context, obj *inspect.OwnedObject) error {
+	restConfig, _, err := util.BuildRestConfigFromConfigOrCurrentClusterContext(o.targetKubeconfig)
+	if err != nil {
+		return fmt.Errorf("cannot build rest config for target cluster: %w", err)
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("cannot build discovery client for target cluster: %w", err)
+	}
+	groupResources, err := restmapper.GetAPIGroupResources(discoveryClient)
+	if err != nil {
+		return fmt.Errorf("cannot discover api resources of target cluster: %w", err)
+	}
+	mapper := restmapper.NewDiscoveryRESTMapper(groupResources)
+
+	kind, group, _ := strings.Cut(o.kind, ".")
+	gvk, err := mapper.KindFor(schema.GroupVersionResource{Group: group, Resource: strings.ToLower(kind)})
+	if err != nil {
+		return fmt.Errorf("cannot resolve kind %s in target cluster: %w", o.kind, err)
+	}
+
+	targetClient, err := client.New(restConfig, client.Options{Mapper: mapper})
+	if err != nil {
+		return fmt.Errorf("cannot build client for target cluster: %w", err)
+	}
+	targetObj := &unstructured.Unstructured{}
+	targetObj.SetGroupVersionKind(gvk)
+	if err := targetClient.Get(ctx, client.ObjectKey{Name: o.name, Namespace: o.namespace}, targetObj); err != nil {
+		return fmt.Errorf("cannot get %s in target cluster: %w", formatOwnedObject(*obj), err)
+	}
+
+	obj.Kind = gvk.Kind
+	annotations := targetObj.GetAnnotations()
+	obj.HelmRelease = annotations[inspect.HelmReleaseNameAnnotation]
+	obj.HelmReleaseNamespace = annotations[inspect.HelmReleaseNamespaceAnnotation]
+	return nil
+}
+
+// formatOwners prints the chain from the deployItem to the root installation for each owner.
+func formatOwners(obj inspect.OwnedObject, owners []*inspect.Owner) (string, error) {
+	out := strings.Builder{}
+	fmt.Fprintf(&out, "%s is managed by:\n", formatOwnedObject(obj))
+
+	for i, owner := range owners {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		target := owner.Target
+		if target == "" {
+			target = "none"
+		}
+		fmt.Fprintf(&out, "Matched by %s, target %s\n", owner.MatchedBy, target)
+
+		w := tabwriter.NewWriter(&out, 0, 0, 3, ' ', 0)
+		for j, link := range owner.Chain {
+			arrow := "→ "
+			if j == 0 {
+				arrow = "  "
+			}
+			fmt.Fprintf(w, "%s%s\t%s/%s\t%s", arrow, link.Kind, link.Namespace, link.Name, formatDependencyPhase(link.Phase))
+			if j == len(owner.Chain)-1 && link.Kind == "Installation" {
+				fmt.Fprint(w, "\troot")
+			}
+			fmt.Fprintln(w)
+		}
+		if err := w.Flush(); err != nil {
+			return "", err
+		}
+	}
+	return out.String(), nil
+}
+
+func formatOwnedObject(obj inspect.OwnedObject) string {
+	if obj.Namespace == "" {
+		return fmt.Sprintf("%s %s", obj.Kind, obj.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", obj.Kind, obj.Namespace, obj.Name)
+}
+
+func (o *ownerOptions) validateArgs(args []string) error {
+	if o.kind == "" || o.name == "" {
+		return fmt.Errorf("the --kind and --name options are required")
+	}
+
+	switch o.omode {
+	case inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid option for '--output'/'-o' flag: %q", o.omode)
+	}
+}
+
+func (o *ownerOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the landscaper cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVar(&o.landscaperNamespace, "landscaper-namespace", "", "namespace of the deployitems in the landscaper cluster. By default, all namespaces are searched.")
+	fs.StringVar(&o.kind, "kind", "", "kind of the object, e.g. Deployment. The kind may be qualified with its api group, e.g. Deployment.apps, which is used to read the object with --target-kubeconfig.")
+	fs.StringVar(&o.name, "name", "", "name of the object.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the object in the target cluster. Empty for cluster scoped objects.")
+	fs.StringVar(&o.targetKubeconfig, "target-kubeconfig", "", "path to the kubeconfig for the target cluster. If set, the object is read from the target cluster, so that also helm deployitems deploying its helm release are found.")
+	fs.StringVarP(&o.omode, "output", "o", inspect.OutputTable, fmt.Sprintf("how the output is formatted. Valid values are %s, %s, and %s.", inspect.OutputTable, OUTPUT_YAML, OUTPUT_JSON))
+}
@@ -2,38 +2,81 @@ package installations
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/pkg/landscaper/installations"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+// Results of reconciling a root installation, besides the final phases which are reported with --wait.
+const (
+	reconcileResultTriggered = "Triggered"
+	reconcileResultError     = "Error"
+	reconcileResultTimeout   = "Timeout"
 )
 
+type reconcileOptions struct {
+	annotationOptions
+
+	installationNames []string
+	selector          string
+	all               bool
+	allNamespaces     bool
+	onlyFailed        bool
+
+	wait     bool
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// reconcileResult is a row of the result table of the reconcile command.
+type reconcileResult struct {
+	installation *v1alpha1.Installation
+	result       string
+	message      string
+}
+
 func NewReconcileCommand(ctx context.Context) *cobra.Command {
-	opts := &annotationOptions{
-		annotationKey:         v1alpha1.OperationAnnotation,
-		annotationValue:       string(v1alpha1.ReconcileOperation),
-		rootInstallationsOnly: true,
+	opts := &reconcileOptions{
+		annotationOptions: annotationOptions{
+			annotationKey:         v1alpha1.OperationAnnotation,
+			annotationValue:       string(v1alpha1.ReconcileOperation),
+			rootInstallationsOnly: true,
+		},
 	}
 
 	cmd := &cobra.Command{
-		Use:     "reconcile [installation-name] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
-		Args:    cobra.MaximumNArgs(1),
-		Example: "landscaper-cli installations reconcile MY_INSTALLATION --namespace MY_NAMESPACE",
-		Short: "Starts a new reconciliation of the specified root installation. If the command is invoked while a " +
-			"reconciliation is already running, the new reconciliation is postponed until the current one has " +
-			"finished. The command is only supported for root installations.",
+		Use:     "reconcile [installation-name...] [--selector selector | --all] [--all-namespaces] [--only-failed] [--wait] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.ArbitraryArgs,
+		Example: "landscaper-cli installations reconcile --all --only-failed --wait --namespace MY_NAMESPACE",
+		Short: "Starts a new reconciliation of the specified root installations. The installations are given by name, " +
+			"by label selector, or with --all. If the command is invoked while a reconciliation is already running, the " +
+			"new reconciliation is postponed until the current one has finished. The command is only supported for " +
+			"root installations.",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := opts.validateArgs(args); err != nil {
 				cmd.PrintErr(err.Error())
 				os.Exit(1)
 			}
 
-			if err := opts.run(ctx); err != nil {
+			if err := opts.run(ctx, cmd); err != nil {
 				cmd.PrintErr(err.Error())
 				os.Exit(1)
 			}
-
-			cmd.Println("The reconcile annotation was added to the installation")
 		},
 	}
 
@@ -43,3 +86,220 @@ func NewReconcileCommand(ctx context.Context) *cobra.Command {
 
 	return cmd
 }
+
+func (o *reconcileOptions) run(ctx context.Context, cmd *cobra.Command) error {
+	kubeClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" && !o.allNamespaces {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	rootInstallations, err := o.selectRootInstallations(ctx, kubeClient)
+	if err != nil {
+		return err
+	}
+	if len(rootInstallations) == 0 {
+		cmd.Println("No matching root installations found")
+		return nil
+	}
+
+	results := []*reconcileResult{}
+	triggered := []*reconcileResult{}
+	for _, inst := range rootInstallations {
+		result := &reconcileResult{installation: inst, result: reconcileResultTriggered}
+		if err := o.annotate(ctx, kubeClient, client.ObjectKeyFromObject(inst)); err != nil {
+			result.result = reconcileResultError
+			result.message = err.Error()
+		} else {
+			triggered = append(triggered, result)
+		}
+		results = append(results, result)
+	}
+
+	if len(o.installationNames) == 1 && !o.wait {
+		if results[0].result == reconcileResultError {
+			return errors.New(results[0].message)
+		}
+		cmd.Println("The reconcile annotation was added to the installation")
+		return nil
+	}
+
+	if o.wait {
+		o.waitForResults(ctx, cmd, kubeClient, triggered)
+	}
+
+	output, err := formatReconcileResults(results)
+	if err != nil {
+		return err
+	}
+	cmd.Print(output)
+
+	notSucceeded := 0
+	for _, result := range results {
+		if result.result != reconcileResultTriggered && result.result != string(v1alpha1.InstallationPhases.Succeeded) {
+			notSucceeded++
+		}
+	}
+	if notSucceeded > 0 {
+		return fmt.Errorf("%d of %d installations have not been reconciled successfully", notSucceeded, len(results))
+	}
+	return nil
+}
+
+// selectRootInstallations returns the named root installations, or the root installations matching the selector or
+// all root installations of the namespace or all namespaces. With --only-failed, only failed installations are
+// returned.
+func (o *reconcileOptions) selectRootInstallations(ctx context.Context, kubeClient client.Client) ([]*v1alpha1.Installation, error) {
+	selected := []*v1alpha1.Installation{}
+
+	if len(o.installationNames) > 0 {
+		for _, name := range o.installationNames {
+			inst := &v1alpha1.Installation{}
+			if err := kubeClient.Get(ctx, client.ObjectKey{Namespace: o.namespace, Name: name}, inst); err != nil {
+				return nil, fmt.Errorf("failed to read installation %s: %w", name, err)
+			}
+			if !installations.IsRootInstallation(inst) {
+				return nil, fmt.Errorf("installation %s is not a root installation: the command is only supported for root installations", name)
+			}
+			selected = append(selected, inst)
+		}
+	} else {
+		listOptions := []client.ListOption{}
+		if !o.allNamespaces {
+			listOptions = append(listOptions, client.InNamespace(o.namespace))
+		}
+		if o.selector != "" {
+			selector, err := labels.Parse(o.selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid label selector %q: %w", o.selector, err)
+			}
+			listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
+		}
+
+		installationList := &v1alpha1.InstallationList{}
+		if err := kubeClient.List(ctx, installationList, listOptions...); err != nil {
+			return nil, fmt.Errorf("failed to list installations: %w", err)
+		}
+		for i := range installationList.Items {
+			if inst := &installationList.Items[i]; installations.IsRootInstallation(inst) {
+				selected = append(selected, inst)
+			}
+		}
+		sort.Slice(selected, func(i, j int) bool {
+			if selected[i].Namespace != selected[j].Namespace {
+				return selected[i].Namespace < selected[j].Namespace
+			}
+			return selected[i].Name < selected[j].Name
+		})
+	}
+
+	if !o.onlyFailed {
+		return selected, nil
+	}
+	failed := []*v1alpha1.Installation{}
+	for _, inst := range selected {
+		if inst.Status.InstallationPhase.IsFailed() {
+			failed = append(failed, inst)
+		}
+	}
+	return failed, nil
+}
+
+// waitForResults polls the triggered root installations until they have finished a new job, and sets their final
+// phase as result. Installations which have not finished within the timeout get the result Timeout.
+func (o *reconcileOptions) waitForResults(ctx context.Context, cmd *cobra.Command, kubeClient client.Client, triggered []*reconcileResult) {
+	pending := map[*reconcileResult]bool{}
+	lastPhases := map[*reconcileResult]v1alpha1.InstallationPhase{}
+	for _, result := range triggered {
+		pending[result] = true
+		lastPhases[result] = result.installation.Status.InstallationPhase
+	}
+
+	_ = wait.PollUntilContextTimeout(ctx, o.interval, o.timeout, false, func(ctx context.Context) (done bool, err error) {
+		for _, result := range triggered {
+			if !pending[result] {
+				continue
+			}
+
+			inst := &v1alpha1.Installation{}
+			if err := kubeClient.Get(ctx, client.ObjectKeyFromObject(result.installation), inst); err != nil {
+				cmd.Printf("- cannot read installation %s - will retry\n", result.installation.Name)
+				continue
+			}
+			if phase := inst.Status.InstallationPhase; phase != lastPhases[result] {
+				cmd.Printf("%s installation %s/%s is in phase %s\n", time.Now().Format(time.TimeOnly), inst.Namespace, inst.Name, phase)
+				lastPhases[result] = phase
+			}
+
+			// the landscaper removes the reconcile annotation when it starts a new job
+			if metav1.HasAnnotation(inst.ObjectMeta, v1alpha1.OperationAnnotation) || inst.Status.JobID != inst.Status.JobIDFinished ||
+				inst.Status.JobID == result.installation.Status.JobID {
+				continue
+			}
+			delete(pending, result)
+			result.installation = inst
+			result.result = string(inst.Status.InstallationPhase)
+			if inst.Status.LastError != nil && inst.Status.InstallationPhase.IsFailed() {
+				result.message = inst.Status.LastError.Message
+			}
+		}
+		return len(pending) == 0, nil
+	})
+
+	for result := range pending {
+		result.result = reconcileResultTimeout
+		result.message = fmt.Sprintf("not finished within %s", o.timeout)
+	}
+}
+
+func formatReconcileResults(results []*reconcileResult) (string, error) {
+	out := strings.Builder{}
+	w := tabwriter.NewWriter(&out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tRESULT\tMESSAGE")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", result.installation.Namespace, result.installation.Name, result.result, result.message)
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func (o *reconcileOptions) validateArgs(args []string) error {
+	o.installationNames = args
+
+	if len(args) > 0 && (o.all || o.allNamespaces || o.selector != "") {
+		return fmt.Errorf("installation names cannot be combined with --all, --all-namespaces, or --selector")
+	}
+	if len(args) == 0 && !o.all && !o.allNamespaces && o.selector == "" {
+		return fmt.Errorf("specify either installation names, --all, --all-namespaces, or --selector")
+	}
+
+	if o.wait {
+		if o.timeout <= 0 {
+			return fmt.Errorf("the timeout must be positive")
+		}
+		if o.interval <= 0 {
+			return fmt.Errorf("the interval must be positive")
+		}
+	}
+	return nil
+}
+
+func (o *reconcileOptions) AddFlags(fs *pflag.FlagSet) {
+	o.annotationOptions.AddFlags(fs)
+	fs.StringVarP(&o.selector, "selector", "l", "", "reconcile the root installations matching the label selector.")
+	fs.BoolVar(&o.all, "all", false, "reconcile all root installations of the namespace.")
+	fs.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "reconcile the root installations of all namespaces. Any given namespace will be ignored.")
+	fs.BoolVar(&o.onlyFailed, "only-failed", false, "reconcile only the selected root installations which are in a failed phase.")
+	fs.BoolVar(&o.wait, "wait", false, "wait until the reconciliation of every triggered installation has finished, and print the final phases.")
+	fs.DurationVar(&o.timeout, "timeout", 15*time.Minute, "together with --wait, the maximal time to wait.")
+	fs.DurationVar(&o.interval, "interval", 5*time.Second, "together with --wait, the time between two checks of the installations.")
+}
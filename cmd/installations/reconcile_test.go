@@ -0,0 +1,173 @@
+package installations
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/gardener/landscaper/test/utils/envtest"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestSelectRootInstallations(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          reconcileOptions
+		expectedNames []string
+		expectedError string
+	}{
+		{
+			name:          "All root installations of the namespace",
+			opts:          reconcileOptions{annotationOptions: annotationOptions{namespace: "test"}, all: true},
+			expectedNames: []string{"test/root-failed", "test/root-succeeded"},
+		},
+		{
+			name:          "Only failed root installations of the namespace",
+			opts:          reconcileOptions{annotationOptions: annotationOptions{namespace: "test"}, all: true, onlyFailed: true},
+			expectedNames: []string{"test/root-failed"},
+		},
+		{
+			name:          "Only failed root installations of all namespaces",
+			opts:          reconcileOptions{allNamespaces: true, onlyFailed: true},
+			expectedNames: []string{"other/root-other", "test/root-failed"},
+		},
+		{
+			name:          "Root installations matching the selector",
+			opts:          reconcileOptions{annotationOptions: annotationOptions{namespace: "test"}, selector: "team=b"},
+			expectedNames: []string{"test/root-succeeded"},
+		},
+		{
+			name:          "Named root installations",
+			opts:          reconcileOptions{annotationOptions: annotationOptions{namespace: "test"}, installationNames: []string{"root-succeeded", "root-failed"}, onlyFailed: true},
+			expectedNames: []string{"test/root-failed"},
+		},
+		{
+			name:          "Named subinstallation",
+			opts:          reconcileOptions{annotationOptions: annotationOptions{namespace: "test"}, installationNames: []string{"sub-failed"}},
+			expectedError: "installation sub-failed is not a root installation: the command is only supported for root installations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+			assert.NoError(t, err)
+
+			selected, err := tt.opts.selectRootInstallations(context.TODO(), fakeClient)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			names := []string{}
+			for _, inst := range selected {
+				names = append(names, inst.Namespace+"/"+inst.Name)
+			}
+			assert.Equal(t, tt.expectedNames, names)
+		})
+	}
+}
+
+// landscaperClient simulates the landscaper: before every read of an installation, the next change is applied to it.
+type landscaperClient struct {
+	client.Client
+	changes []func(inst *lsv1alpha1.Installation)
+}
+
+func (c *landscaperClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if inst, ok := obj.(*lsv1alpha1.Installation); ok && len(c.changes) > 0 {
+		if err := c.Client.Get(ctx, key, inst); err != nil {
+			return err
+		}
+		c.changes[0](inst)
+		c.changes = c.changes[1:]
+		status := inst.Status
+		if err := c.Client.Update(ctx, inst); err != nil {
+			return err
+		}
+		inst.Status = status
+		if err := c.Client.Status().Update(ctx, inst); err != nil {
+			return err
+		}
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestWaitForResults(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	kubeClient := &landscaperClient{
+		Client: fakeClient,
+		changes: []func(inst *lsv1alpha1.Installation){
+			// the reconcile annotation has not yet been processed
+			func(inst *lsv1alpha1.Installation) {},
+			// the landscaper starts a new job
+			func(inst *lsv1alpha1.Installation) {
+				delete(inst.Annotations, lsv1alpha1.OperationAnnotation)
+				inst.Status.JobID = "job-2"
+				inst.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Progressing
+			},
+			func(inst *lsv1alpha1.Installation) {},
+			// the new job has finished
+			func(inst *lsv1alpha1.Installation) {
+				inst.Status.JobIDFinished = "job-2"
+				inst.Status.InstallationPhase = lsv1alpha1.InstallationPhases.Succeeded
+				inst.Status.LastError = nil
+			},
+		},
+	}
+
+	o := &reconcileOptions{
+		annotationOptions: annotationOptions{
+			annotationKey:         lsv1alpha1.OperationAnnotation,
+			annotationValue:       string(lsv1alpha1.ReconcileOperation),
+			rootInstallationsOnly: true,
+		},
+		timeout:  10 * time.Second,
+		interval: time.Millisecond,
+	}
+	key := client.ObjectKey{Namespace: "test", Name: "root-failed"}
+	assert.NoError(t, o.annotate(context.TODO(), fakeClient, key))
+	inst := &lsv1alpha1.Installation{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), key, inst))
+	assert.True(t, metav1.HasAnnotation(inst.ObjectMeta, lsv1alpha1.OperationAnnotation))
+
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	result := &reconcileResult{installation: inst, result: reconcileResultTriggered}
+	o.waitForResults(context.TODO(), cmd, kubeClient, []*reconcileResult{result})
+
+	// the result is only set after the new job has finished, although the old job had already finished
+	assert.Empty(t, kubeClient.changes)
+	assert.Equal(t, string(lsv1alpha1.InstallationPhases.Succeeded), result.result)
+	assert.Empty(t, result.message)
+	assert.Equal(t, "job-2", result.installation.Status.JobIDFinished)
+	assert.Contains(t, out.String(), "installation test/root-failed is in phase Progressing\n")
+	assert.Contains(t, out.String(), "installation test/root-failed is in phase Succeeded\n")
+}
+
+func TestWaitForResultsTimeout(t *testing.T) {
+	fakeClient, _, err := envtest.NewFakeClientFromPath("./testdata")
+	assert.NoError(t, err)
+
+	inst := &lsv1alpha1.Installation{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), client.ObjectKey{Namespace: "test", Name: "root-failed"}, inst))
+
+	// the landscaper never starts a new job, so the finished job of the previous reconciliation must not be reported
+	o := &reconcileOptions{timeout: 50 * time.Millisecond, interval: 5 * time.Millisecond}
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	result := &reconcileResult{installation: inst, result: reconcileResultTriggered}
+	o.waitForResults(context.TODO(), cmd, fakeClient, []*reconcileResult{result})
+
+	assert.Equal(t, reconcileResultTimeout, result.result)
+	assert.Equal(t, "not finished within 50ms", result.message)
+}
@@ -0,0 +1,187 @@
+package installations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+const (
+	// waitExitCodeFailed is the exit code of the wait command if an object of the installation tree has failed
+	waitExitCodeFailed = 2
+	// waitExitCodeTimeout is the exit code of the wait command if the timeout has been reached
+	waitExitCodeTimeout = 3
+)
+
+type waitOptions struct {
+	kubeconfig       string
+	installationName string
+	namespace        string
+	forCondition     string
+	timeout          time.Duration
+	interval         time.Duration
+
+	phase string
+}
+
+func NewWaitCommand(ctx context.Context) *cobra.Command {
+	opts := &waitOptions{}
+	cmd := &cobra.Command{
+		Use:     "wait [installation-name] [--for phase=Succeeded] [--timeout 15m] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.ExactArgs(1),
+		Example: "landscaper-cli installations wait MY_INSTALLATION --namespace MY_NAMESPACE --for phase=Succeeded --timeout 15m",
+		Short: "Waits until the specified root installation has finished its current job and the installation and all its " +
+			"subobjects have reached the specified phase. Objects which belong to an outdated job do not count. For the " +
+			"phases Failed and DeleteFailed, only the phase of the root installation is checked. " +
+			fmt.Sprintf("The command exits with code 0 if the phase is reached, with code %d if an object has failed, ", waitExitCodeFailed) +
+			fmt.Sprintf("with code %d if the timeout is reached, and with code 1 on all other errors.", waitExitCodeTimeout),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			status, err := opts.run(ctx, cmd, logger.Log)
+			if err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			switch status {
+			case inspect.PhaseConditionReached:
+				cmd.Printf("Installation %s has reached phase %s\n", opts.installationName, opts.phase)
+			case inspect.PhaseConditionFailed:
+				cmd.PrintErrf("Installation %s has failed\n", opts.installationName)
+				os.Exit(waitExitCodeFailed)
+			default:
+				cmd.PrintErrf("Timeout: installation %s has not reached phase %s within %s\n", opts.installationName, opts.phase, opts.timeout)
+				os.Exit(waitExitCodeTimeout)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *waitOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) (inspect.PhaseConditionStatus, error) {
+	k8sClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return inspect.PhaseConditionPending, fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return inspect.PhaseConditionPending, fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+	}
+
+	status := inspect.PhaseConditionPending
+	var installationTree *inspect.InstallationTree
+	var lastErr error
+	lastRootPhase := lsv1alpha1.InstallationPhase("")
+
+	_ = wait.PollUntilContextTimeout(ctx, o.interval, o.timeout, true, func(ctx context.Context) (done bool, err error) {
+		installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+		if err != nil {
+			lastErr = fmt.Errorf("cannot collect installation: %w", err)
+			cmd.Printf("- cannot collect installation %s - will retry\n", o.installationName)
+			return false, nil
+		}
+		lastErr = nil
+		installationTree = installationTrees[0]
+
+		if rootPhase := installationTree.Installation.Status.InstallationPhase; rootPhase != lastRootPhase {
+			cmd.Printf("%s installation %s is in phase %s\n", time.Now().Format(time.TimeOnly), o.installationName, rootPhase)
+			lastRootPhase = rootPhase
+		}
+
+		status = inspect.CheckPhaseCondition(installationTree, o.phase)
+		return status != inspect.PhaseConditionPending, nil
+	})
+
+	if installationTree == nil {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("installation %s could not be collected within %s", o.installationName, o.timeout)
+		}
+		return status, lastErr
+	}
+
+	if status != inspect.PhaseConditionReached {
+		if err := o.printFailingBranch(cmd, installationTree); err != nil {
+			return status, err
+		}
+	}
+
+	return status, nil
+}
+
+// printFailingBranch prints all objects of the tree which have not reached the expected phase.
+func (o *waitOptions) printFailingBranch(cmd *cobra.Command, installationTree *inspect.InstallationTree) error {
+	failingBranch := installationTree.FilterForPhaseNotReached(o.phase)
+	if failingBranch == nil {
+		return nil
+	}
+
+	transformer := inspect.NewTransformer(false, false, false, false)
+	transformedTrees, err := transformer.TransformToPrintableTrees([]*inspect.InstallationTree{failingBranch})
+	if err != nil {
+		return fmt.Errorf("error transforming CR to printable tree: %w", err)
+	}
+	output := inspect.PrintTrees(transformedTrees)
+
+	cmd.Printf("\nObjects which have not reached phase %s:\n\n", o.phase)
+	cmd.Print(output.String())
+	return nil
+}
+
+func (o *waitOptions) validateArgs(args []string) error {
+	o.installationName = args[0]
+
+	condition, found := strings.CutPrefix(o.forCondition, "phase=")
+	if !found {
+		return fmt.Errorf("invalid condition %q: only conditions of the form 'phase=PHASE' are supported", o.forCondition)
+	}
+	if phase := lsv1alpha1.InstallationPhase(condition); !phase.IsFinal() {
+		return fmt.Errorf("invalid phase %q: only the final phases %s, %s, and %s are supported", condition,
+			lsv1alpha1.InstallationPhases.Succeeded, lsv1alpha1.InstallationPhases.Failed, lsv1alpha1.InstallationPhases.DeleteFailed)
+	}
+	o.phase = condition
+
+	if o.timeout <= 0 {
+		return fmt.Errorf("the timeout must be positive")
+	}
+	if o.interval <= 0 {
+		return fmt.Errorf("the interval must be positive")
+	}
+	return nil
+}
+
+func (o *waitOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+	fs.StringVar(&o.forCondition, "for", "phase="+string(lsv1alpha1.InstallationPhases.Succeeded), "the condition to wait for, e.g. 'phase=Succeeded'.")
+	fs.DurationVar(&o.timeout, "timeout", 15*time.Minute, "the maximal time to wait.")
+	fs.DurationVar(&o.interval, "interval", 5*time.Second, "the time between two checks of the installation.")
+}
@@ -0,0 +1,270 @@
+package installations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	inspect "github.com/gardener/landscapercli/cmd/installations/inspect"
+	"github.com/gardener/landscapercli/pkg/logger"
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+const (
+	// watchRedrawInterval is the minimal time between two redraws of the tree
+	watchRedrawInterval = 500 * time.Millisecond
+	// watchRetryInterval is the time to wait before a failed watch is started again
+	watchRetryInterval = 5 * time.Second
+
+	clearScreen = "\033[H\033[2J"
+)
+
+type watchOptions struct {
+	kubeconfig       string
+	installationName string
+	namespace        string
+	omode            string
+
+	allNamespaces  bool
+	detailMode     bool
+	showOnlyFailed bool
+	owide          bool
+
+	k8sClient client.WithWatch
+}
+
+func NewWatchCommand(ctx context.Context) *cobra.Command {
+	opts := &watchOptions{}
+	cmd := &cobra.Command{
+		Use:     "watch [installation-name] [--namespace namespace] [--kubeconfig kubeconfig.yaml]",
+		Args:    cobra.MaximumNArgs(1),
+		Example: "landscaper-cli installations watch MY_INSTALLATION --namespace MY_NAMESPACE",
+		Short: "Displays the same tree as the inspect command, but keeps it up to date by watching installations, " +
+			"executions and deployItems. Objects whose phase has changed since the last update are highlighted. " +
+			"Stop the command with Ctrl+C.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := opts.validateArgs(args); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+
+			if err := opts.run(ctx, cmd, logger.Log); err != nil {
+				cmd.PrintErr(err.Error())
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.SetOut(os.Stdout)
+
+	opts.AddFlags(cmd.Flags())
+
+	return cmd
+}
+
+func (o *watchOptions) run(ctx context.Context, cmd *cobra.Command, log logr.Logger) error {
+	k8sClient, namespace, err := util.BuildKubeWatchClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+	o.k8sClient = k8sClient
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.allNamespaces {
+		if len(o.installationName) != 0 {
+			return fmt.Errorf("the --all-namespaces option cannot be used when an installation name is provided")
+		}
+		o.namespace = "*"
+	}
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	switch o.omode {
+	case OUTPUT_WIDE:
+		o.owide = true
+	case "":
+	default:
+		return fmt.Errorf("invalid option for '--output'/'-o' flag: %q. Only %q is supported", o.omode, OUTPUT_WIDE)
+	}
+
+	collector := inspect.Collector{
+		K8sClient: k8sClient,
+	}
+	installationTrees, err := collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+	if err != nil {
+		return fmt.Errorf("cannot collect installation: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events := o.startWatches(ctx, cmd)
+
+	transformer := inspect.NewTransformer(o.detailMode, o.showOnlyFailed, o.allNamespaces, o.owide).
+		WithPhaseTracker(inspect.NewPhaseTracker())
+	if err := o.redraw(cmd, transformer, installationTrees); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchRedrawInterval)
+	defer ticker.Stop()
+
+	changed := false
+	collectAgain := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event := <-events:
+			obj, ok := event.Object.(client.Object)
+			if !ok {
+				continue
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				if o.updateInstallationTrees(installationTrees, obj) {
+					changed = true
+				} else if o.isRelevant(installationTrees, obj) {
+					collectAgain = true
+				}
+			case watch.Deleted:
+				if o.isRelevant(installationTrees, obj) {
+					collectAgain = true
+				}
+			}
+
+		case <-ticker.C:
+			if collectAgain {
+				installationTrees, err = collector.CollectInstallationsInCluster(ctx, o.installationName, o.namespace)
+				if err != nil {
+					return fmt.Errorf("cannot collect installation: %w", err)
+				}
+				collectAgain = false
+				changed = true
+			}
+			if changed {
+				if err := o.redraw(cmd, transformer, installationTrees); err != nil {
+					return err
+				}
+				changed = false
+			}
+		}
+	}
+}
+
+// startWatches watches installations, executions and deployItems and merges their events into one channel.
+// Watches which are closed by the API server are started again until the context is cancelled.
+func (o *watchOptions) startWatches(ctx context.Context, cmd *cobra.Command) <-chan watch.Event {
+	events := make(chan watch.Event)
+
+	listOptions := []client.ListOption{}
+	if o.namespace != "*" {
+		listOptions = append(listOptions, client.InNamespace(o.namespace))
+	}
+
+	for _, list := range []client.ObjectList{
+		&lsv1alpha1.InstallationList{},
+		&lsv1alpha1.ExecutionList{},
+		&lsv1alpha1.DeployItemList{},
+	} {
+		go func(list client.ObjectList) {
+			for ctx.Err() == nil {
+				watcher, err := o.k8sClient.Watch(ctx, list, listOptions...)
+				if err != nil {
+					cmd.PrintErrf("- cannot start watch - will retry: %s\n", err.Error())
+					select {
+					case <-ctx.Done():
+					case <-time.After(watchRetryInterval):
+					}
+					continue
+				}
+
+				for event := range watcher.ResultChan() {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						watcher.Stop()
+						return
+					}
+				}
+			}
+		}(list)
+	}
+
+	return events
+}
+
+// updateInstallationTrees replaces the given object in the installation trees. It returns false if no tree
+// contains the object or the structure of a tree has changed.
+func (o *watchOptions) updateInstallationTrees(installationTrees []*inspect.InstallationTree, obj client.Object) bool {
+	for _, installationTree := range installationTrees {
+		if installationTree.UpdateObject(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRelevant returns true if the added or deleted object belongs to the displayed installation trees.
+func (o *watchOptions) isRelevant(installationTrees []*inspect.InstallationTree, obj client.Object) bool {
+	if o.installationName == "" {
+		return true
+	}
+	if _, ok := obj.(*lsv1alpha1.Installation); ok && obj.GetName() == o.installationName {
+		return true
+	}
+	for _, installationTree := range installationTrees {
+		if installationTree.ContainsParentOf(obj) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *watchOptions) redraw(cmd *cobra.Command, transformer *inspect.Transformer, installationTrees []*inspect.InstallationTree) error {
+	// the transformer filters the trees in place, therefore the trees are copied
+	treesToTransform := make([]*inspect.InstallationTree, 0, len(installationTrees))
+	for _, installationTree := range installationTrees {
+		treesToTransform = append(treesToTransform, installationTree.DeepCopy())
+	}
+
+	transformedTrees, err := transformer.TransformToPrintableTrees(treesToTransform)
+	if err != nil {
+		return fmt.Errorf("error transforming CR to printable tree: %w", err)
+	}
+	output := inspect.PrintTrees(transformedTrees)
+
+	cmd.Print(clearScreen)
+	cmd.Printf("Last update: %s\n\n", time.Now().Format(time.TimeOnly))
+	cmd.Print(output.String())
+	return nil
+}
+
+func (o *watchOptions) validateArgs(args []string) error {
+	if len(args) == 1 {
+		o.installationName = args[0]
+	}
+	return nil
+}
+
+func (o *watchOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", "namespace of the installation. Required if --kubeconfig is used.")
+	fs.BoolVarP(&o.allNamespaces, "all-namespaces", "A", false, "if present, watches installations across all namespaces. No installation name may be given and any given namespace will be ignored.")
+	fs.BoolVarP(&o.detailMode, "show-details", "d", false, "show detailed information about installations, executions and deployitems. Similar to kubectl describe installation installation-name.")
+	fs.BoolVarP(&o.showOnlyFailed, "show-failed", "f", false, "show only items that are in phase 'Failed'. It also prints parent elements to the failed items.")
+	fs.BoolVarP(&o.owide, "owide", "w", false, "output some additional information. Equivalent to '-o wide'.")
+	fs.StringVarP(&o.omode, "output", "o", "", fmt.Sprintf("how the output is formatted. The only valid value is %s.", OUTPUT_WIDE))
+}
@@ -1,6 +1,7 @@
 package blueprints
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 
@@ -34,3 +35,45 @@ func (r *BlueprintReader) Read() (*v1alpha1.Blueprint, error) {
 
 	return blueprint, nil
 }
+
+// ReadFilesystem returns the files of the blueprint directory as filesystem of an inline blueprint, in which
+// directories are maps and files are strings with the file content.
+func (r *BlueprintReader) ReadFilesystem() (v1alpha1.AnyJSON, error) {
+	filesystem, err := readDirectory(r.blueprintPath)
+	if err != nil {
+		return v1alpha1.AnyJSON{}, err
+	}
+
+	data, err := json.Marshal(filesystem)
+	if err != nil {
+		return v1alpha1.AnyJSON{}, err
+	}
+	return v1alpha1.NewAnyJSON(data), nil
+}
+
+func readDirectory(path string) (map[string]interface{}, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	filesystem := map[string]interface{}{}
+	for _, entry := range entries {
+		entryPath := filepath.Join(path, entry.Name())
+		if entry.IsDir() {
+			subdirectory, err := readDirectory(entryPath)
+			if err != nil {
+				return nil, err
+			}
+			filesystem[entry.Name()] = subdirectory
+			continue
+		}
+
+		data, err := os.ReadFile(entryPath)
+		if err != nil {
+			return nil, err
+		}
+		filesystem[entry.Name()] = string(data)
+	}
+	return filesystem, nil
+}
@@ -0,0 +1,288 @@
+package blueprints
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gardener/landscaper/apis/core/v1alpha1"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+// InstallationBuilder fills the imports and exports of an installation from the import and export definitions of a
+// blueprint.
+type InstallationBuilder struct {
+	blueprint *v1alpha1.Blueprint
+
+	// importValues are the values of data imports, given by import name.
+	importValues map[string]string
+	// importTargets are the targets of target imports, given by import name.
+	importTargets map[string]string
+
+	// comments are the comments of the imports in the installation manifest, given by import name.
+	comments map[string]string
+}
+
+func NewInstallationBuilder(blueprint *v1alpha1.Blueprint) *InstallationBuilder {
+	return &InstallationBuilder{
+		blueprint:     blueprint,
+		importValues:  map[string]string{},
+		importTargets: map[string]string{},
+		comments:      map[string]string{},
+	}
+}
+
+// SetImportValue sets the value of a data import. The value is parsed as yaml 1.2, so that e.g. numbers and lists can
+// be given.
+func (b *InstallationBuilder) SetImportValue(name, value string) {
+	b.importValues[name] = value
+}
+
+// SetImportTarget sets the target of a target import. For targetList imports, the targets are separated by commas.
+// For targetMap imports, the entries are given as comma separated key:target pairs.
+func (b *InstallationBuilder) SetImportTarget(name, target string) {
+	b.importTargets[name] = target
+}
+
+// Build sets the imports, importDataMappings and exports of the installation. Data imports with a value are set as
+// importDataMappings, optional data imports with a default value as importDataMappings stub with the default value,
+// and all other data imports as references to a DataObject with the name of the import. Target imports reference a
+// Target with the name of the import, unless a target is set. Exports are written to DataObjects and Targets with the
+// name of the export.
+func (b *InstallationBuilder) Build(installation *v1alpha1.Installation) error {
+	importDefinitions := b.importDefinitions()
+	if err := b.checkImportNames(importDefinitions); err != nil {
+		return err
+	}
+
+	for _, importDefinition := range importDefinitions {
+		name := importDefinition.Name
+		b.comments[name] = "optional"
+		if importDefinition.Required == nil || *importDefinition.Required {
+			b.comments[name] = "required"
+		}
+
+		switch importTypeOf(importDefinition) {
+		case v1alpha1.ImportTypeTarget, v1alpha1.ImportTypeTargetList, v1alpha1.ImportTypeTargetMap:
+			targetImport, err := b.buildTargetImport(importDefinition)
+			if err != nil {
+				return err
+			}
+			installation.Spec.Imports.Targets = append(installation.Spec.Imports.Targets, *targetImport)
+
+		default:
+			if value, ok := b.importValues[name]; ok {
+				var parsedValue interface{}
+				if err := yamlv3.Unmarshal([]byte(value), &parsedValue); err != nil {
+					return fmt.Errorf("invalid value for import %s: %w", name, err)
+				}
+				if err := b.setImportDataMapping(installation, name, parsedValue); err != nil {
+					return err
+				}
+			} else if b.comments[name] == "optional" && len(importDefinition.Default.Value.RawMessage) > 0 {
+				if installation.Spec.ImportDataMappings == nil {
+					installation.Spec.ImportDataMappings = map[string]v1alpha1.AnyJSON{}
+				}
+				installation.Spec.ImportDataMappings[name] = importDefinition.Default.Value
+			} else {
+				installation.Spec.Imports.Data = append(installation.Spec.Imports.Data, v1alpha1.DataImport{
+					Name:    name,
+					DataRef: name,
+				})
+			}
+		}
+	}
+
+	for _, exportDefinition := range b.blueprint.Exports {
+		if exportDefinition.Type == v1alpha1.ExportTypeTarget {
+			installation.Spec.Exports.Targets = append(installation.Spec.Exports.Targets, v1alpha1.TargetExport{
+				Name:   exportDefinition.Name,
+				Target: exportDefinition.Name,
+			})
+		} else {
+			installation.Spec.Exports.Data = append(installation.Spec.Exports.Data, v1alpha1.DataExport{
+				Name:    exportDefinition.Name,
+				DataRef: exportDefinition.Name,
+			})
+		}
+	}
+
+	return nil
+}
+
+// Marshal returns the installation as yaml manifest, in which the imports are marked as required or optional in
+// comments. Build must have been called before.
+func (b *InstallationBuilder) Marshal(installation *v1alpha1.Installation) ([]byte, error) {
+	installation.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	installation.Kind = "Installation"
+
+	data, err := yaml.Marshal(installation)
+	if err != nil {
+		return nil, err
+	}
+
+	node := &yamlv3.Node{}
+	if err := yamlv3.Unmarshal(data, node); err != nil {
+		return nil, err
+	}
+	root := node.Content[0]
+	removeMappingKey(root, "status")
+	removeMappingKey(mappingValue(root, "metadata"), "creationTimestamp")
+
+	spec := mappingValue(root, "spec")
+	for _, importList := range mappingValue(spec, "imports").Content {
+		for _, importNode := range importList.Content {
+			moveMappingKeyFirst(importNode, "name")
+			nameNode := mappingValue(importNode, "name")
+			nameNode.LineComment = b.comments[nameNode.Value]
+		}
+	}
+	for _, exportList := range mappingValue(spec, "exports").Content {
+		for _, exportNode := range exportList.Content {
+			moveMappingKeyFirst(exportNode, "name")
+		}
+	}
+	mappings := mappingValue(spec, "importDataMappings")
+	for i := 0; i < len(mappings.Content)-1; i += 2 {
+		mappings.Content[i].LineComment = b.comments[mappings.Content[i].Value]
+	}
+
+	return util.MarshalYaml(node)
+}
+
+// importDefinitions returns the import definitions of the blueprint including conditional imports, which are always
+// optional.
+func (b *InstallationBuilder) importDefinitions() []v1alpha1.ImportDefinition {
+	importDefinitions := []v1alpha1.ImportDefinition{}
+	var add func(definitions v1alpha1.ImportDefinitionList, conditional bool)
+	add = func(definitions v1alpha1.ImportDefinitionList, conditional bool) {
+		for _, importDefinition := range definitions {
+			if conditional {
+				optional := false
+				importDefinition.Required = &optional
+			}
+			importDefinitions = append(importDefinitions, importDefinition)
+			add(importDefinition.ConditionalImports, true)
+		}
+	}
+	add(b.blueprint.Imports, false)
+	return importDefinitions
+}
+
+func (b *InstallationBuilder) checkImportNames(importDefinitions []v1alpha1.ImportDefinition) error {
+	dataImports := map[string]bool{}
+	targetImports := map[string]bool{}
+	for _, importDefinition := range importDefinitions {
+		switch importTypeOf(importDefinition) {
+		case v1alpha1.ImportTypeTarget, v1alpha1.ImportTypeTargetList, v1alpha1.ImportTypeTargetMap:
+			targetImports[importDefinition.Name] = true
+		default:
+			dataImports[importDefinition.Name] = true
+		}
+	}
+
+	for _, name := range sortedKeys(b.importValues) {
+		if !dataImports[name] {
+			return fmt.Errorf("the blueprint has no data import %s", name)
+		}
+	}
+	for _, name := range sortedKeys(b.importTargets) {
+		if !targetImports[name] {
+			return fmt.Errorf("the blueprint has no target import %s", name)
+		}
+	}
+	return nil
+}
+
+func (b *InstallationBuilder) buildTargetImport(importDefinition v1alpha1.ImportDefinition) (*v1alpha1.TargetImport, error) {
+	name := importDefinition.Name
+	target, ok := b.importTargets[name]
+	if !ok {
+		target = name
+	}
+
+	targetImport := &v1alpha1.TargetImport{Name: name}
+	switch importDefinition.Type {
+	case v1alpha1.ImportTypeTargetList:
+		targetImport.Targets = strings.Split(target, ",")
+	case v1alpha1.ImportTypeTargetMap:
+		targetImport.TargetMap = map[string]string{}
+		if !ok {
+			targetImport.TargetMap[name] = target
+			break
+		}
+		for _, entry := range strings.Split(target, ",") {
+			key, mappedTarget, found := strings.Cut(entry, ":")
+			if !found {
+				return nil, fmt.Errorf("invalid target for targetMap import %s: expected key:target pairs separated by commas", name)
+			}
+			targetImport.TargetMap[key] = mappedTarget
+		}
+	default:
+		targetImport.Target = target
+	}
+	return targetImport, nil
+}
+
+func (b *InstallationBuilder) setImportDataMapping(installation *v1alpha1.Installation, name string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("invalid value for import %s: %w", name, err)
+	}
+	if installation.Spec.ImportDataMappings == nil {
+		installation.Spec.ImportDataMappings = map[string]v1alpha1.AnyJSON{}
+	}
+	installation.Spec.ImportDataMappings[name] = v1alpha1.NewAnyJSON(raw)
+	return nil
+}
+
+// importTypeOf returns the type of an import. Imports without type are target imports if they have a target type,
+// and data imports otherwise.
+func importTypeOf(importDefinition v1alpha1.ImportDefinition) v1alpha1.ImportType {
+	if importDefinition.Type == "" && importDefinition.TargetType != "" {
+		return v1alpha1.ImportTypeTarget
+	}
+	return importDefinition.Type
+}
+
+// mappingValue returns the value of a key of a yaml mapping node, or an empty node if the key does not exist.
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return &yamlv3.Node{}
+}
+
+func moveMappingKeyFirst(node *yamlv3.Node, key string) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			entry := []*yamlv3.Node{node.Content[i], node.Content[i+1]}
+			node.Content = append(entry, append(node.Content[:i:i], node.Content[i+2:]...)...)
+			return
+		}
+	}
+}
+
+func removeMappingKey(node *yamlv3.Node, key string) {
+	for i := 0; i < len(node.Content)-1; i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
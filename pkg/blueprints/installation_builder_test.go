@@ -0,0 +1,73 @@
+package blueprints
+
+import (
+	"testing"
+
+	"github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const testBlueprint = `
+apiVersion: landscaper.gardener.cloud/v1alpha1
+kind: Blueprint
+imports:
+- name: cluster
+  targetType: landscaper.gardener.cloud/kubernetes-cluster
+- name: clusters
+  type: targetList
+  targetType: landscaper.gardener.cloud/kubernetes-cluster
+- name: replicas
+  type: data
+  required: false
+  default:
+    value: 2
+- name: release
+  type: data
+- name: config
+  type: data
+exports:
+- name: url
+  type: data
+- name: exportedCluster
+  type: target
+  targetType: landscaper.gardener.cloud/kubernetes-cluster
+`
+
+func TestInstallationBuilder(t *testing.T) {
+	blueprint := &v1alpha1.Blueprint{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testBlueprint), blueprint))
+
+	builder := NewInstallationBuilder(blueprint)
+	builder.SetImportValue("release", "{name: test, namespace: y}")
+	builder.SetImportTarget("clusters", "a,b")
+
+	installation := &v1alpha1.Installation{}
+	installation.Name = "test"
+	assert.NoError(t, builder.Build(installation))
+
+	assert.Equal(t, []v1alpha1.DataImport{{Name: "config", DataRef: "config"}}, installation.Spec.Imports.Data)
+	assert.Equal(t, []v1alpha1.TargetImport{
+		{Name: "cluster", Target: "cluster"},
+		{Name: "clusters", Targets: []string{"a", "b"}},
+	}, installation.Spec.Imports.Targets)
+	assert.JSONEq(t, `{"name": "test", "namespace": "y"}`, string(installation.Spec.ImportDataMappings["release"].RawMessage))
+	assert.JSONEq(t, `2`, string(installation.Spec.ImportDataMappings["replicas"].RawMessage))
+	assert.Equal(t, []v1alpha1.DataExport{{Name: "url", DataRef: "url"}}, installation.Spec.Exports.Data)
+	assert.Equal(t, []v1alpha1.TargetExport{{Name: "exportedCluster", Target: "exportedCluster"}}, installation.Spec.Exports.Targets)
+
+	manifest, err := builder.Marshal(installation)
+	assert.NoError(t, err)
+	assert.Contains(t, string(manifest), "- name: config # required\n        dataRef: config\n")
+	assert.Contains(t, string(manifest), "replicas: 2 # optional\n")
+	assert.NotContains(t, string(manifest), "status:")
+}
+
+func TestInstallationBuilderUnknownImport(t *testing.T) {
+	blueprint := &v1alpha1.Blueprint{}
+	assert.NoError(t, yaml.Unmarshal([]byte(testBlueprint), blueprint))
+
+	builder := NewInstallationBuilder(blueprint)
+	builder.SetImportTarget("release", "my-target")
+	assert.EqualError(t, builder.Build(&v1alpha1.Installation{}), "the blueprint has no target import release")
+}
@@ -0,0 +1,113 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/gardener/landscapercli/pkg/util"
+)
+
+// ApplyFunc sets the annotation of the operation on the object, which has been read from the cluster. It can refuse
+// operations which are not supported for the object by returning an error.
+type ApplyFunc func(obj client.Object, op *Operation) error
+
+// CommandOptions are the options of the operation commands, which trigger an operation on an installation or a
+// deployItem.
+type CommandOptions struct {
+	objectType string
+	operations []*Operation
+
+	kubeconfig    string
+	name          string
+	namespace     string
+	operationName string
+	triggeredBy   string
+
+	operation *Operation
+}
+
+// NewCommandOptions returns the options of an operation command for objects of the given type, e.g. "installation",
+// which supports the given operations.
+func NewCommandOptions(objectType string, operations []*Operation) *CommandOptions {
+	return &CommandOptions{
+		objectType: objectType,
+		operations: operations,
+	}
+}
+
+// Run returns the run function of the command. newObject returns an empty object of the type of the command, and apply
+// sets the annotation of the operation on it.
+func (o *CommandOptions) Run(ctx context.Context, scheme *runtime.Scheme, newObject func() client.Object, apply ApplyFunc) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := o.validateArgs(args); err != nil {
+			cmd.PrintErr(err.Error())
+			os.Exit(1)
+		}
+
+		if err := o.run(ctx, scheme, newObject(), apply); err != nil {
+			cmd.PrintErr(err.Error())
+			os.Exit(1)
+		}
+
+		cmd.Printf("The %s operation was triggered on the %s\n", o.operation.Name, o.objectType)
+	}
+}
+
+func (o *CommandOptions) run(ctx context.Context, scheme *runtime.Scheme, obj client.Object, apply ApplyFunc) error {
+	kubeClient, namespace, err := util.BuildKubeClientFromConfigOrCurrentClusterContext(o.kubeconfig, scheme)
+	if err != nil {
+		return fmt.Errorf("cannot build k8s client from config or current cluster context: %w", err)
+	}
+
+	if namespace != "" && o.namespace == "" {
+		o.namespace = namespace
+	}
+
+	if o.namespace == "" {
+		return fmt.Errorf("namespace was not defined. Use --namespace to specify a namespace")
+	}
+
+	return o.apply(ctx, kubeClient, obj, apply)
+}
+
+func (o *CommandOptions) apply(ctx context.Context, kubeClient client.Client, obj client.Object, apply ApplyFunc) error {
+	if o.triggeredBy == "" {
+		o.triggeredBy = DefaultTriggeredBy()
+	}
+
+	key := client.ObjectKey{Namespace: o.namespace, Name: o.name}
+	err := Apply(ctx, kubeClient, key, obj, o.triggeredBy, func() error {
+		return apply(obj, o.operation)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to annotate %s %s: %w", o.objectType, o.name, err)
+	}
+	return nil
+}
+
+func (o *CommandOptions) validateArgs(args []string) error {
+	o.name = args[0]
+
+	if o.operationName == "" {
+		return fmt.Errorf("the --op option is required")
+	}
+	op, err := Find(o.operations, o.operationName)
+	if err != nil {
+		return err
+	}
+	o.operation = op
+	return nil
+}
+
+func (o *CommandOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig for the cluster. Required if the cluster is not the same as the current-context of kubectl.")
+	fs.StringVarP(&o.namespace, "namespace", "n", "", fmt.Sprintf("namespace of the %s. Required if --kubeconfig is used.", o.objectType))
+	fs.StringVar(&o.operationName, "op", "", "the operation to trigger, one of the operations listed above.")
+	fs.StringVar(&o.triggeredBy, "triggered-by", "", "who triggered the operation, recorded in the annotation "+TriggeredByAnnotation+". By default, the user and host name.")
+}
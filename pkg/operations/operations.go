@@ -0,0 +1,149 @@
+package operations
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	lsv1alpha1helper "github.com/gardener/landscaper/apis/core/v1alpha1/helper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TriggeredByAnnotation records who triggered the last operation on a landscaper object, and when.
+const TriggeredByAnnotation = "landscapercli.gardener.cloud/triggered-by"
+
+// Operation is an operation of the landscaper which is triggered by annotating an installation or a deployItem.
+type Operation struct {
+	// Name is the value of the operation annotation, or the name of the annotation without the landscaper domain.
+	Name        string
+	Description string
+	// RootOnly is set for installation operations which are only supported for root installations.
+	RootOnly bool
+
+	annotate func(obj *metav1.ObjectMeta)
+}
+
+// InstallationOperations are the operations which can be triggered on installations.
+var InstallationOperations = []*Operation{
+	operationAnnotation(lsv1alpha1.ReconcileOperation, true,
+		"starts a new reconciliation of the root installation and all its subobjects."),
+	operationAnnotation(lsv1alpha1.ForceReconcileOperation, true,
+		"sets the force-reconcile operation, which is currently not used by the landscaper."),
+	operationAnnotation(lsv1alpha1.InterruptOperation, false,
+		"changes the installation and its subobjects with an unfinished phase to phase Failed."),
+	trueAnnotation(lsv1alpha1.DeleteWithoutUninstallAnnotation, true,
+		"lets the landscaper delete the root installation without uninstalling the deployed software."),
+	trueAnnotation(lsv1alpha1.ReconcileIfChangedAnnotation, false,
+		"lets the landscaper start a reconciliation automatically if the spec of the installation has changed."),
+}
+
+// DeployItemOperations are the operations which can be triggered on deployItems.
+var DeployItemOperations = []*Operation{
+	operationAnnotation(lsv1alpha1.TestReconcileOperation, false,
+		"starts a reconciliation of the deployItem. It is intended for tests and must not be used productively."),
+	{
+		Name:        annotationName(lsv1alpha1.ReconcileTimestampAnnotation),
+		Description: "sets the reconcile timestamp to now, from which the landscaper computes the timeout of the deployItem.",
+		annotate: func(obj *metav1.ObjectMeta) {
+			lsv1alpha1helper.SetTimestampAnnotationNow(obj, lsv1alpha1helper.ReconcileTimestamp)
+		},
+	},
+}
+
+func operationAnnotation(op lsv1alpha1.Operation, rootOnly bool, description string) *Operation {
+	return &Operation{
+		Name:        string(op),
+		Description: description,
+		RootOnly:    rootOnly,
+		annotate: func(obj *metav1.ObjectMeta) {
+			lsv1alpha1helper.SetOperation(obj, op)
+		},
+	}
+}
+
+func trueAnnotation(annotation string, rootOnly bool, description string) *Operation {
+	return &Operation{
+		Name:        annotationName(annotation),
+		Description: description,
+		RootOnly:    rootOnly,
+		annotate: func(obj *metav1.ObjectMeta) {
+			metav1.SetMetaDataAnnotation(obj, annotation, "true")
+		},
+	}
+}
+
+func annotationName(annotation string) string {
+	return strings.TrimPrefix(annotation, lsv1alpha1.LandscaperDomain+"/")
+}
+
+// Find returns the operation with the given name.
+func Find(operations []*Operation, name string) (*Operation, error) {
+	for _, op := range operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q, valid operations are %s", name, strings.Join(Names(operations), ", "))
+}
+
+// Names returns the names of the operations.
+func Names(operations []*Operation) []string {
+	names := make([]string, 0, len(operations))
+	for _, op := range operations {
+		names = append(names, op.Name)
+	}
+	return names
+}
+
+// Describe lists the operations with their descriptions, e.g. for the help text of a command.
+func Describe(operations []*Operation) string {
+	out := strings.Builder{}
+	for _, op := range operations {
+		fmt.Fprintf(&out, "- %s: %s\n", op.Name, op.Description)
+	}
+	return out.String()
+}
+
+// Annotate sets the annotation of the operation.
+func (op *Operation) Annotate(obj *metav1.ObjectMeta) {
+	op.annotate(obj)
+}
+
+// Apply reads the object, changes it with mutate, records who triggered the change in the TriggeredByAnnotation,
+// and updates the object. If the update fails because the object has been modified in the meantime, the object is
+// read again and the change is repeated. An error returned by mutate stops the retries.
+func Apply(ctx context.Context, kubeClient client.Client, key client.ObjectKey, obj client.Object, triggeredBy string, mutate func() error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := kubeClient.Get(ctx, key, obj); err != nil {
+			return err
+		}
+		if err := mutate(); err != nil {
+			return err
+		}
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[TriggeredByAnnotation] = fmt.Sprintf("%s at %s", triggeredBy, time.Now().UTC().Format(time.RFC3339))
+		obj.SetAnnotations(annotations)
+		return kubeClient.Update(ctx, obj)
+	})
+}
+
+// DefaultTriggeredBy returns the name of the current user of the operating system and the host name.
+func DefaultTriggeredBy() string {
+	userName := "unknown"
+	if current, err := user.Current(); err == nil {
+		userName = current.Username
+	}
+	if hostName, err := os.Hostname(); err == nil {
+		return fmt.Sprintf("%s@%s", userName, hostName)
+	}
+	return userName
+}
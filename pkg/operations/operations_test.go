@@ -0,0 +1,107 @@
+package operations
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	lsv1alpha1 "github.com/gardener/landscaper/apis/core/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestFind(t *testing.T) {
+	op, err := Find(InstallationOperations, "delete-without-uninstall")
+	assert.NoError(t, err)
+	assert.True(t, op.RootOnly)
+
+	obj := &metav1.ObjectMeta{}
+	op.Annotate(obj)
+	assert.Equal(t, "true", obj.Annotations[lsv1alpha1.DeleteWithoutUninstallAnnotation])
+
+	op, err = Find(DeployItemOperations, "reconcile-time")
+	assert.NoError(t, err)
+	op.Annotate(obj)
+	assert.Contains(t, obj.Annotations, lsv1alpha1.ReconcileTimestampAnnotation)
+
+	_, err = Find(DeployItemOperations, "reconcile")
+	assert.ErrorContains(t, err, "valid operations are test-reconcile, reconcile-time")
+}
+
+func TestApply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, lsv1alpha1.AddToScheme(scheme))
+	installation := &lsv1alpha1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+	// the first update fails with a conflict, as if the landscaper had updated the installation in the meantime
+	conflicts := 0
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(installation).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if conflicts == 0 {
+				conflicts++
+				return apierrors.NewConflict(schema.GroupResource{Resource: "installations"}, obj.GetName(), nil)
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	op, err := Find(InstallationOperations, string(lsv1alpha1.ReconcileOperation))
+	assert.NoError(t, err)
+
+	mutations := 0
+	result := &lsv1alpha1.Installation{}
+	err = Apply(context.TODO(), kubeClient, client.ObjectKeyFromObject(installation), result, "someone", func() error {
+		mutations++
+		op.Annotate(&result.ObjectMeta)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, mutations)
+
+	assert.NoError(t, kubeClient.Get(context.TODO(), client.ObjectKeyFromObject(installation), result))
+	assert.Equal(t, "reconcile", result.Annotations[lsv1alpha1.OperationAnnotation])
+	assert.True(t, strings.HasPrefix(result.Annotations[TriggeredByAnnotation], "someone at "))
+}
+
+func TestCommandOptions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, lsv1alpha1.AddToScheme(scheme))
+	installation := &lsv1alpha1.Installation{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+	kubeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(installation).Build()
+
+	o := NewCommandOptions("installation", InstallationOperations)
+	assert.EqualError(t, o.validateArgs([]string{"test"}), "the --op option is required")
+	o.operationName = "test-reconcile"
+	assert.ErrorContains(t, o.validateArgs([]string{"test"}), `unknown operation "test-reconcile"`)
+
+	o.operationName = "interrupt"
+	o.namespace = "default"
+	o.triggeredBy = "someone"
+	assert.NoError(t, o.validateArgs([]string{"test"}))
+
+	var applied *Operation
+	err := o.apply(context.TODO(), kubeClient, &lsv1alpha1.Installation{}, func(obj client.Object, op *Operation) error {
+		applied = op
+		op.Annotate(&obj.(*lsv1alpha1.Installation).ObjectMeta)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "interrupt", applied.Name)
+
+	result := &lsv1alpha1.Installation{}
+	assert.NoError(t, kubeClient.Get(context.TODO(), client.ObjectKeyFromObject(installation), result))
+	assert.Equal(t, "interrupt", result.Annotations[lsv1alpha1.OperationAnnotation])
+	assert.True(t, strings.HasPrefix(result.Annotations[TriggeredByAnnotation], "someone at "))
+
+	o.name = "unknown"
+	err = o.apply(context.TODO(), kubeClient, &lsv1alpha1.Installation{}, func(obj client.Object, op *Operation) error {
+		return nil
+	})
+	assert.ErrorContains(t, err, "failed to annotate installation unknown")
+}
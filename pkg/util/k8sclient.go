@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"k8s.io/client-go/tools/clientcmd"
@@ -14,19 +15,9 @@ import (
 
 // GetK8sClientFromCurrentConfiguredCluster returns a k8sClient and a namespace from the current context of the kubectl program.
 func GetK8sClientFromCurrentConfiguredCluster() (client.Client, string, error) {
-	rules := clientcmd.NewDefaultClientConfigLoadingRules()
-	rules.DefaultClientConfig = &clientcmd.DefaultClientConfig
-
-	overrides := &clientcmd.ConfigOverrides{ClusterDefaults: clientcmd.ClusterDefaults}
-	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
-	cfg, err := clientConfig.ClientConfig()
-
+	cfg, namespace, err := getRestConfigFromCurrentConfiguredCluster()
 	if err != nil {
-		return nil, "", fmt.Errorf("cannot build k8s config %w", err)
-	}
-	namespace, _, err := clientConfig.Namespace()
-	if err != nil {
-		return nil, "", fmt.Errorf("error extracting namespace from current k8s context. %w", err)
+		return nil, "", err
 	}
 
 	scheme := runtime.NewScheme()
@@ -66,3 +57,58 @@ func BuildKubeClientFromConfigOrCurrentClusterContext(kubeconfig string, scheme
 
 	return k8sClient, namespace, nil
 }
+
+// BuildKubeWatchClientFromConfigOrCurrentClusterContext returns a k8sClient which additionally supports watches.
+// Like BuildKubeClientFromConfigOrCurrentClusterContext, the namespace is only returned if no kubeconfig is given.
+func BuildKubeWatchClientFromConfigOrCurrentClusterContext(kubeconfig string, scheme *runtime.Scheme) (client.WithWatch, string, error) {
+	cfg, namespace, err := BuildRestConfigFromConfigOrCurrentClusterContext(kubeconfig)
+	if err != nil {
+		return nil, "", err
+	}
+
+	k8sClient, err := client.NewWithWatch(cfg, client.Options{
+		Scheme: scheme,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot build K8s client: %w", err)
+	}
+
+	return k8sClient, namespace, nil
+}
+
+// BuildRestConfigFromConfigOrCurrentClusterContext returns the rest config for the given kubeconfig or for the current
+// context of the kubectl program. The namespace is only returned if no kubeconfig is given.
+func BuildRestConfigFromConfigOrCurrentClusterContext(kubeconfig string) (*rest.Config, string, error) {
+	if kubeconfig != "" {
+		cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+		if err != nil {
+			return nil, "", fmt.Errorf("cannot parse K8s config: %w", err)
+		}
+		return cfg, "", nil
+	}
+
+	cfg, namespace, err := getRestConfigFromCurrentConfiguredCluster()
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot build K8s client from current cluster config: %w", err)
+	}
+	return cfg, namespace, nil
+}
+
+func getRestConfigFromCurrentConfiguredCluster() (*rest.Config, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.DefaultClientConfig = &clientcmd.DefaultClientConfig
+
+	overrides := &clientcmd.ConfigOverrides{ClusterDefaults: clientcmd.ClusterDefaults}
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+	cfg, err := clientConfig.ClientConfig()
+
+	if err != nil {
+		return nil, "", fmt.Errorf("cannot build k8s config %w", err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("error extracting namespace from current k8s context. %w", err)
+	}
+
+	return cfg, namespace, nil
+}